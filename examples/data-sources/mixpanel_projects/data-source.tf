@@ -0,0 +1,8 @@
+data "mixpanel_projects" "web" {
+  name_regex = "^web-"
+  domain     = "EU"
+}
+
+output "web_project_ids" {
+  value = data.mixpanel_projects.web.projects[*].id
+}
@@ -0,0 +1,21 @@
+resource "mixpanel_project" "myproject" {
+  name          = "myproject"
+  domain        = "EU"
+  timezone      = "Europe/Paris"
+  store_secrets = false
+}
+
+ephemeral "mixpanel_project_credentials" "myproject" {
+  project_id = mixpanel_project.myproject.id
+}
+
+resource "vault_kv_secret_v2" "mixpanel" {
+  mount = "secret"
+  name  = "mixpanel/myproject"
+  data_json_wo = jsonencode({
+    api_key = ephemeral.mixpanel_project_credentials.myproject.api_key
+    secret  = ephemeral.mixpanel_project_credentials.myproject.secret
+  })
+  # Increment to write the credentials again
+  data_json_wo_version = 1
+}
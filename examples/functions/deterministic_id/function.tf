@@ -0,0 +1,7 @@
+locals {
+  test_users = ["alice", "bob"]
+
+  distinct_ids = {
+    for user in local.test_users : user => provider::mixpanel::deterministic_id("test-users", user)
+  }
+}
@@ -0,0 +1,8 @@
+variable "insert_id" {
+  type = string
+
+  validation {
+    condition     = provider::mixpanel::is_valid_insert_id(var.insert_id)
+    error_message = "The insert ID must be at most 36 alphanumeric characters or dashes."
+  }
+}
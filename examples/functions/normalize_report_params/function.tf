@@ -0,0 +1,3 @@
+locals {
+  signups_params = provider::mixpanel::normalize_report_params(file("${path.module}/reports/signups.json"))
+}
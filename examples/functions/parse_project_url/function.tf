@@ -0,0 +1,7 @@
+locals {
+  board = provider::mixpanel::parse_project_url("https://eu.mixpanel.com/project/1234/view/56/app/boards#id=789")
+}
+
+output "board_project_id" {
+  value = local.board.id # 1234
+}
@@ -0,0 +1,3 @@
+output "board_url" {
+  value = provider::mixpanel::project_url(mixpanel_project.myproject.id, mixpanel_project.myproject.domain, "view/123/app/boards#id=456")
+}
@@ -0,0 +1,11 @@
+locals {
+  schema        = file("${path.module}/schemas/events.json")
+  schema_errors = provider::mixpanel::validate_schema(local.schema)
+}
+
+check "schema" {
+  assert {
+    condition     = length(local.schema_errors) == 0
+    error_message = join("\n", local.schema_errors)
+  }
+}
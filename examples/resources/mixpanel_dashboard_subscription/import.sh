@@ -0,0 +1,2 @@
+# Dashboard subscriptions can be imported by specifying the project, board and subscription identifiers.
+terraform import mixpanel_dashboard_subscription.example 123/456/789
@@ -0,0 +1,16 @@
+resource "mixpanel_dashboard_subscription" "weekly_kpis" {
+  project_id   = mixpanel_project.myproject.id
+  dashboard_id = 123456
+  channel      = "email"
+  recipients   = ["product@example.com", "growth@example.com"]
+  schedule     = "0 9 * * MON"
+  timezone     = "Europe/Paris"
+}
+
+resource "mixpanel_dashboard_subscription" "daily_slack" {
+  project_id    = mixpanel_project.myproject.id
+  dashboard_id  = 123456
+  channel       = "slack"
+  slack_channel = "#product-metrics"
+  schedule      = "30 8 * * *"
+}
@@ -0,0 +1,2 @@
+# Drop filters can be imported by specifying the project and filter identifiers.
+terraform import mixpanel_drop_filter.example 123/456
@@ -0,0 +1,21 @@
+# Strip a property that leaked personal data from every checkout event
+resource "mixpanel_drop_filter" "checkout_email" {
+  project_id         = mixpanel_project.myproject.id
+  event_name_pattern = "Checkout *"
+  properties         = ["customer_email"]
+  description        = "customer_email leaked from the web checkout, see INC-1234"
+}
+
+# Drop internal test traffic entirely
+resource "mixpanel_drop_filter" "qa_traffic" {
+  project_id         = mixpanel_project.myproject.id
+  event_name_pattern = "*"
+
+  conditions = [
+    {
+      property = "$email"
+      operator = "contains"
+      value    = "@qa.example.com"
+    },
+  ]
+}
@@ -0,0 +1,35 @@
+resource "mixpanel_project" "staging" {
+  name     = "staging"
+  domain   = "EU"
+  timezone = "Europe/Paris"
+}
+
+resource "mixpanel_event_import" "baseline" {
+  project_id = mixpanel_project.staging.id
+
+  events = [
+    {
+      event = "Sign Up"
+      properties = jsonencode({
+        time        = 1717200000
+        distinct_id = "demo-user-1"
+        plan        = "free"
+      })
+    },
+    {
+      event = "Purchase"
+      properties = jsonencode({
+        time         = 1717203600
+        distinct_id  = "demo-user-1"
+        "$insert_id" = "demo-purchase-1"
+        amount       = 42
+      })
+    },
+  ]
+}
+
+# Events from an NDJSON file, imported again when the file changes
+resource "mixpanel_event_import" "fixtures" {
+  project_id = mixpanel_project.staging.id
+  file       = "${path.module}/fixtures/events.ndjson"
+}
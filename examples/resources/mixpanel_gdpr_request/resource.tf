@@ -0,0 +1,7 @@
+resource "mixpanel_gdpr_request" "ticket_4521" {
+  project_id      = mixpanel_project.myproject.id
+  distinct_ids    = ["user-1234", "user-5678"]
+  type            = "deletion"
+  compliance_type = "GDPR"
+  wait_timeout    = "1h"
+}
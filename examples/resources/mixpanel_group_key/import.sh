@@ -0,0 +1,2 @@
+# Group keys can be imported by specifying the project and group key identifiers.
+terraform import mixpanel_group_key.example 123/4
@@ -0,0 +1,5 @@
+resource "mixpanel_group_key" "company" {
+  project_id    = mixpanel_project.myproject.id
+  property_name = "company_id"
+  display_name  = "Company"
+}
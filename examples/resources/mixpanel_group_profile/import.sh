@@ -0,0 +1,2 @@
+# Group profiles can be imported by specifying the project ID, the group key and the group ID, separated by "/".
+terraform import mixpanel_group_profile.example 123/company_id/acme
@@ -0,0 +1,17 @@
+resource "mixpanel_group_key" "company" {
+  project_id    = mixpanel_project.myproject.id
+  property_name = "company_id"
+  display_name  = "Company"
+}
+
+resource "mixpanel_group_profile" "acme" {
+  project_id = mixpanel_project.myproject.id
+  group_key  = mixpanel_group_key.company.property_name
+  group_id   = "acme"
+
+  properties = jsonencode({
+    "$name" = "Acme"
+    plan    = "enterprise"
+    seats   = 50
+  })
+}
@@ -0,0 +1,16 @@
+resource "mixpanel_project_secret_rotation" "myproject" {
+  project_id = mixpanel_project.myproject.id
+
+  keepers = {
+    # Change to rotate the secret again
+    rotation = "2024-06"
+  }
+}
+
+resource "vault_kv_secret_v2" "mixpanel" {
+  mount = "secret"
+  name  = "mixpanel/myproject"
+  data_json = jsonencode({
+    secret = mixpanel_project_secret_rotation.myproject.secret
+  })
+}
@@ -0,0 +1,2 @@
+# Session replay settings can be imported by specifying the project identifier.
+terraform import mixpanel_session_replay_settings.example 123
@@ -0,0 +1,8 @@
+resource "mixpanel_session_replay_settings" "myproject" {
+  project_id      = mixpanel_project.myproject.id
+  sampling_rate   = 0.1
+  mask_all_text   = true
+  mask_all_inputs = true
+  mask_all_images = true
+  retention_days  = 30
+}
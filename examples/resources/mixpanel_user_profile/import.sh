@@ -0,0 +1,2 @@
+# User profiles can be imported by specifying the project ID and the distinct ID, separated by "/".
+terraform import mixpanel_user_profile.example 123/qa-account-1
@@ -0,0 +1,16 @@
+resource "mixpanel_user_profile" "qa" {
+  project_id  = mixpanel_project.myproject.id
+  distinct_id = "qa-account-1"
+
+  properties = jsonencode({
+    "$email" = "qa-1@example.com"
+    "$name"  = "QA Account 1"
+    role     = "qa"
+  })
+
+  set_once = jsonencode({
+    first_seen = "2024-01-01T00:00:00"
+  })
+
+  unset = ["legacy_plan"]
+}
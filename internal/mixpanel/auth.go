@@ -0,0 +1,44 @@
+package mixpanel
+
+import (
+	"net/http"
+)
+
+// Authenticator adds credentials to the requests sent to Mixpanel.
+type Authenticator interface {
+	Authenticate(req *http.Request) error
+}
+
+// ServiceAccountAuthenticator authenticates with a service account, which
+// is accepted by the app, query and export APIs.
+type ServiceAccountAuthenticator struct {
+	Username string
+	Secret   string
+}
+
+func (a *ServiceAccountAuthenticator) Authenticate(req *http.Request) error {
+	req.SetBasicAuth(a.Username, a.Secret)
+	return nil
+}
+
+// ProjectSecretAuthenticator authenticates with the API secret of a single
+// project, as used by the ingestion and export APIs.
+type ProjectSecretAuthenticator struct {
+	Secret string
+}
+
+func (a *ProjectSecretAuthenticator) Authenticate(req *http.Request) error {
+	// The secret is sent as the username with an empty password
+	req.SetBasicAuth(a.Secret, "")
+	return nil
+}
+
+// TokenAuthenticator authenticates with an OAuth or session bearer token.
+type TokenAuthenticator struct {
+	Token string
+}
+
+func (a *TokenAuthenticator) Authenticate(req *http.Request) error {
+	req.Header.Set("Authorization", "Bearer "+a.Token)
+	return nil
+}
@@ -0,0 +1,74 @@
+package mixpanel_test
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"testing"
+
+	"terraform-provider-mixpanel/internal/mixpanel"
+)
+
+func TestAuthenticators(t *testing.T) {
+	tests := []struct {
+		name          string
+		authenticator mixpanel.Authenticator
+		expected      string
+	}{
+		{
+			"service account",
+			&mixpanel.ServiceAccountAuthenticator{Username: "sa.user.mp-service-account", Secret: "s3cr3t"},
+			"Basic " + base64.StdEncoding.EncodeToString([]byte("sa.user.mp-service-account:s3cr3t")),
+		},
+		{
+			// Characters that are not valid in a header must be encoded, not sent as is
+			"service account with special characters",
+			&mixpanel.ServiceAccountAuthenticator{Username: "user@example.com", Secret: "p@ss:wörd/+="},
+			"Basic " + base64.StdEncoding.EncodeToString([]byte("user@example.com:p@ss:wörd/+=")),
+		},
+		{
+			"project secret",
+			&mixpanel.ProjectSecretAuthenticator{Secret: "0123456789abcdef"},
+			"Basic " + base64.StdEncoding.EncodeToString([]byte("0123456789abcdef:")),
+		},
+		{
+			"token",
+			&mixpanel.TokenAuthenticator{Token: "abc.def"},
+			"Bearer abc.def",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req, err := http.NewRequest("GET", "https://mixpanel.com/api/app/me", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := test.authenticator.Authenticate(req); err != nil {
+				t.Fatal(err)
+			}
+			if got := req.Header.Get("Authorization"); got != test.expected {
+				t.Errorf("expected Authorization %q, got %q", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestClientAuthorizationHeader(t *testing.T) {
+	client, server := newTestClient(t)
+
+	if _, err := client.GetTimezones(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	requests := server.Requests()
+	if len(requests) != 1 {
+		t.Fatalf("expected a single request, got %d", len(requests))
+	}
+
+	expected := "Basic " + base64.StdEncoding.EncodeToString([]byte("test:test"))
+	if got := requests[0].Header.Get("Authorization"); got != expected {
+		t.Errorf("expected Authorization %q, got %q", expected, got)
+	}
+}
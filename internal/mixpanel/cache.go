@@ -0,0 +1,74 @@
+package mixpanel
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// How long reference data, such as timezones and organizations, is reused.
+const ReferenceCacheTTL = 10 * time.Minute
+
+// How long a shared lookup of reference data may take. It does not depend on
+// the context of the caller starting it, as other callers may join it.
+const ReferenceFetchTimeout = 5 * time.Minute
+
+// referenceCache keeps reference data for the lifetime of a provider
+// configuration. Concurrent lookups of the same key share a single request.
+type referenceCache struct {
+	ttl     time.Duration
+	group   singleflight.Group
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	value   interface{}
+	expires time.Time
+}
+
+func newReferenceCache(ttl time.Duration) *referenceCache {
+	return &referenceCache{
+		ttl:     ttl,
+		entries: make(map[string]cacheEntry),
+	}
+}
+
+// get returns the cached value of key, calling fetch when it is missing or
+// expired. Errors are not cached.
+func (c *referenceCache) get(ctx context.Context, key string, fetch func(context.Context) (interface{}, error)) (interface{}, error) {
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok && time.Now().Before(entry.expires) {
+		return entry.value, nil
+	}
+
+	ch := c.group.DoChan(key, func() (interface{}, error) {
+		// The lookup is shared, canceling the first caller must not fail the
+		// others. The values of ctx, such as the logger, are kept
+		fetchCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), ReferenceFetchTimeout)
+		defer cancel()
+
+		value, err := fetch(fetchCtx)
+		if err != nil {
+			return nil, err
+		}
+
+		c.mu.Lock()
+		c.entries[key] = cacheEntry{value: value, expires: time.Now().Add(c.ttl)}
+		c.mu.Unlock()
+
+		return value, nil
+	})
+
+	// Callers joining a lookup stop waiting when their own context is done
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case result := <-ch:
+		return result.Val, result.Err
+	}
+}
@@ -0,0 +1,112 @@
+package mixpanel
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestReferenceCacheTTL(t *testing.T) {
+	cache := newReferenceCache(50 * time.Millisecond)
+	ctx := context.Background()
+
+	var fetches int32
+	fetch := func(context.Context) (interface{}, error) {
+		return atomic.AddInt32(&fetches, 1), nil
+	}
+
+	for i := 0; i < 3; i++ {
+		value, err := cache.get(ctx, "timezones", fetch)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if value != int32(1) {
+			t.Fatalf("expected the cached value, got %v", value)
+		}
+	}
+
+	time.Sleep(60 * time.Millisecond)
+
+	value, err := cache.get(ctx, "timezones", fetch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value != int32(2) {
+		t.Errorf("expected the value to be fetched again after the TTL, got %v", value)
+	}
+
+	// Errors are not cached
+	failure := errors.New("unavailable")
+	for i := 0; i < 2; i++ {
+		if _, err := cache.get(ctx, "organizations", func(context.Context) (interface{}, error) {
+			atomic.AddInt32(&fetches, 1)
+			return nil, failure
+		}); !errors.Is(err, failure) {
+			t.Fatalf("expected %v, got %v", failure, err)
+		}
+	}
+	if fetches != 4 {
+		t.Errorf("expected 4 fetches, got %d", fetches)
+	}
+}
+
+func TestReferenceCacheConcurrentLookups(t *testing.T) {
+	cache := newReferenceCache(time.Minute)
+
+	var fetches int32
+	started := make(chan struct{})
+	release := make(chan struct{})
+	fetch := func(ctx context.Context) (interface{}, error) {
+		if atomic.AddInt32(&fetches, 1) == 1 {
+			close(started)
+		}
+		<-release
+		// The shared lookup outlives the caller starting it
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		return "value", nil
+	}
+
+	// The first caller gives up while the lookup is in progress
+	firstCtx, cancelFirst := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := cache.get(firstCtx, "me", fetch)
+		firstErr <- err
+	}()
+	<-started
+
+	var wg sync.WaitGroup
+	results := make([]interface{}, 10)
+	errs := make([]error, 10)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], errs[i] = cache.get(context.Background(), "me", fetch)
+		}(i)
+	}
+
+	cancelFirst()
+	if err := <-firstErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the first caller to be canceled, got %v", err)
+	}
+
+	// Let the other callers join the lookup before it completes
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	for i := range results {
+		if errs[i] != nil || results[i] != "value" {
+			t.Errorf("caller %d: got %v, %v", i, results[i], errs[i])
+		}
+	}
+	if fetches != 1 {
+		t.Errorf("expected a single fetch, got %d", fetches)
+	}
+}
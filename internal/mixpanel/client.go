@@ -1,11 +1,12 @@
 package mixpanel
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net/http"
+	"os"
 
-	"github.com/hashicorp/go-retryablehttp"
 	"golang.org/x/sync/semaphore"
 )
 
@@ -13,38 +14,87 @@ import (
 const HostURL string = "https://mixpanel.com"
 
 type Client struct {
-	HostURL    string
-	HTTPClient *http.Client
-	AuthHeader string
-	Semaphore  *semaphore.Weighted
+	// HostURL is the host of the organization and project management API.
+	HostURL string
+	// APIHost overrides every regional host when set, e.g. to test against a
+	// local stand-in of the Mixpanel API.
+	APIHost       string
+	HTTPClient    *http.Client
+	Authenticator Authenticator
+	Semaphore     *semaphore.Weighted
+	// RateLimiters spread the requests of each API family over its hourly
+	// budget. Families without a limiter are not limited.
+	RateLimiters map[APIFamily]*RateLimiter
+	// cache holds reference data shared by every resource configured from
+	// the same provider block.
+	cache *referenceCache
 }
 
-func NewClient(serviceAccountUsername, serviceAccountSecret *string, concurrentRequests int64) (*Client, error) {
-
-	retryClient := retryablehttp.NewClient()
-	retryClient.Backoff = retryablehttp.DefaultBackoff
+// NewClient creates a Mixpanel API client. When requestsPerHour is zero, only
+// the query API is limited, to its documented budget; otherwise every API
+// family is limited to requestsPerHour.
+func NewClient(authenticator Authenticator, concurrentRequests int64, retryPolicy RetryPolicy, requestsPerHour int64) (*Client, error) {
+	retryClient := newRetryClient(retryPolicy)
+	if os.Getenv(LogLevelEnv) != "" {
+		retryClient.HTTPClient.Transport = newLoggingTransport(retryClient.HTTPClient.Transport)
+	}
 
 	c := Client{
 		HTTPClient: retryClient.StandardClient(),
-		// Default Hashicups URL
-		HostURL: HostURL,
+		HostURL:    HostURL,
+		cache:      newReferenceCache(ReferenceCacheTTL),
 	}
 
-	if serviceAccountUsername == nil || serviceAccountSecret == nil {
-		return nil, fmt.Errorf("missing service account credentials")
+	if authenticator == nil {
+		return nil, fmt.Errorf("missing authenticator")
 	}
 
-	c.AuthHeader = "Basic " + *serviceAccountUsername + ":" + *serviceAccountSecret
+	c.Authenticator = authenticator
 
 	c.Semaphore = semaphore.NewWeighted(concurrentRequests)
 
+	if requestsPerHour > 0 {
+		c.RateLimiters = map[APIFamily]*RateLimiter{
+			APIFamilyApp:       NewRateLimiter(requestsPerHour),
+			APIFamilyQuery:     NewRateLimiter(requestsPerHour),
+			APIFamilyIngestion: NewRateLimiter(requestsPerHour),
+		}
+	} else {
+		c.RateLimiters = map[APIFamily]*RateLimiter{
+			APIFamilyQuery: NewRateLimiter(QueryRequestsPerHour),
+		}
+	}
+
 	return &c, nil
 }
 
+// WithAuthenticator returns a copy of the client sending requests with other
+// credentials, e.g. a project secret for the ingestion API. The copy shares
+// the HTTP client, the concurrency limit and the cache of the original.
+func (c *Client) WithAuthenticator(authenticator Authenticator) *Client {
+	clone := *c
+	clone.Authenticator = authenticator
+	return &clone
+}
+
 func (c *Client) doRequest(req *http.Request) ([]byte, error) {
-	req.Header.Add("Authorization", c.AuthHeader)
+	req = req.WithContext(withAttemptCounter(logContext(req.Context())))
 
-	err := c.Semaphore.Acquire(req.Context(), 1)
+	err := c.Authenticator.Authenticate(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Wait for the rate limit before taking a concurrency slot, so that a
+	// limited API does not hold back requests to the others
+	if limiter, ok := c.RateLimiters[apiFamily(req)]; ok {
+		err = limiter.Wait(req.Context())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = c.Semaphore.Acquire(req.Context(), 1)
 	if err != nil {
 		return nil, err
 	}
@@ -56,10 +106,23 @@ func (c *Client) doRequest(req *http.Request) ([]byte, error) {
 	defer res.Body.Close()
 
 	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
 
 	if res.StatusCode < 200 || res.StatusCode >= 300 {
-		return nil, fmt.Errorf("status: %d, body: %s", res.StatusCode, body)
+		return nil, newAPIError(res, body)
+	}
+
+	return body, nil
+}
+
+// cached returns the cached value of key, calling fetch on a cache miss.
+// Clients not created with NewClient always fetch.
+func (c *Client) cached(ctx context.Context, key string, fetch func(context.Context) (interface{}, error)) (interface{}, error) {
+	if c.cache == nil {
+		return fetch(ctx)
 	}
 
-	return body, err
+	return c.cache.get(ctx, key, fetch)
 }
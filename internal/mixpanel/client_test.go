@@ -0,0 +1,347 @@
+package mixpanel_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"terraform-provider-mixpanel/internal/mixpanel"
+	"terraform-provider-mixpanel/internal/mixpanel/mixpaneltest"
+)
+
+func newTestClient(t *testing.T) (*mixpanel.Client, *mixpaneltest.Server) {
+	server := mixpaneltest.NewServer()
+	t.Cleanup(server.Close)
+
+	retryPolicy := mixpanel.RetryPolicy{
+		MaxRetries:   2,
+		RetryWaitMin: 10 * time.Millisecond,
+		RetryWaitMax: 50 * time.Millisecond,
+	}
+
+	client, err := mixpanel.NewClient(&mixpanel.ServiceAccountAuthenticator{Username: "test", Secret: "test"}, 3, retryPolicy, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.HostURL = server.URL
+	client.APIHost = server.URL
+
+	return client, server
+}
+
+func TestClientCreateProject(t *testing.T) {
+	client, server := newTestClient(t)
+	ctx := context.Background()
+
+	for _, name := range []string{"first", "second"} {
+		created, err := client.CreateProject(ctx, &mixpanel.Project{Name: name, Domain: mixpanel.RegionEU, Timezone: "Europe/Paris"})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		project, err := client.GetProject(ctx, created.Id)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if project.Name != name || project.Domain != mixpanel.RegionEU || project.Timezone != "Europe/Paris" {
+			t.Errorf("unexpected project: %+v", project)
+		}
+	}
+
+	// Reference data is fetched once for both projects
+	if count := server.RequestCount(http.MethodGet, "/api/app/timezones"); count != 1 {
+		t.Errorf("expected timezones to be fetched once, got %d", count)
+	}
+	if count := server.RequestCount(http.MethodGet, "/api/app/me"); count != 1 {
+		t.Errorf("expected organizations to be fetched once, got %d", count)
+	}
+}
+
+func TestClientNotFound(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	_, err := client.GetProject(context.Background(), 1)
+
+	var notFound *mixpanel.NotFoundError
+	if !errors.As(err, &notFound) {
+		t.Fatalf("expected a NotFoundError, got %v", err)
+	}
+	if notFound.Message != "project 1 not found" {
+		t.Errorf("unexpected message: %q", notFound.Message)
+	}
+}
+
+func TestClientRetry(t *testing.T) {
+	client, server := newTestClient(t)
+	project := server.AddProject(mixpanel.Project{Name: "test"})
+	path := "/settings/project/"
+
+	server.Fail(mixpaneltest.Failure{Path: path, StatusCode: http.StatusTooManyRequests, Times: 1})
+	server.Fail(mixpaneltest.Failure{Path: path, StatusCode: http.StatusBadGateway, Times: 1})
+
+	if _, err := client.GetProject(context.Background(), project.Id); err != nil {
+		t.Fatal(err)
+	}
+
+	if count := len(server.Requests()); count != 3 {
+		t.Errorf("expected 3 attempts, got %d", count)
+	}
+}
+
+func TestClientRateLimitError(t *testing.T) {
+	client, server := newTestClient(t)
+
+	// Waiting longer than RetryWaitMax is not worth a retry
+	server.Fail(mixpaneltest.Failure{StatusCode: http.StatusTooManyRequests, RetryAfter: time.Hour})
+
+	_, err := client.GetProject(context.Background(), 1)
+
+	var rateLimited *mixpanel.RateLimitError
+	if !errors.As(err, &rateLimited) {
+		t.Fatalf("expected a RateLimitError, got %v", err)
+	}
+	if rateLimited.RetryAfter != time.Hour {
+		t.Errorf("unexpected Retry-After: %s", rateLimited.RetryAfter)
+	}
+	if count := len(server.Requests()); count != 1 {
+		t.Errorf("expected a single attempt, got %d", count)
+	}
+}
+
+func TestClientGetProjects(t *testing.T) {
+	client, server := newTestClient(t)
+	server.AddOrganization(mixpanel.Organization{Id: 2, Name: "Other"})
+	first := server.AddOrganizationProject(2, mixpanel.Project{Name: "first", Domain: "in.mixpanel.com"})
+	second := server.AddProject(mixpanel.Project{Name: "second"})
+
+	projects, err := client.GetProjects(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []mixpanel.ProjectSummary{
+		{Id: first.Id, Name: "first", OrganizationId: 2, Domain: mixpanel.RegionIN, Timezone: "UTC"},
+		{Id: second.Id, Name: "second", OrganizationId: mixpaneltest.DefaultOrganization.Id, Domain: mixpanel.RegionUS, Timezone: "UTC"},
+	}
+	if !reflect.DeepEqual(projects, want) {
+		t.Errorf("got %+v, want %+v", projects, want)
+	}
+}
+
+func TestClientGetEventVolume(t *testing.T) {
+	client, server := newTestClient(t)
+	ctx := context.Background()
+
+	project := server.AddProject(mixpanel.Project{Name: "test"})
+
+	volume, err := client.GetEventVolume(ctx, project.Id, mixpanel.RegionUS, mixpanel.EventVolumeDays)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if volume != 0 {
+		t.Errorf("expected no events, got %d", volume)
+	}
+
+	server.AddEvents(project.Id, "Sign Up", 3)
+	server.AddEvents(project.Id, "Purchase", 2)
+
+	volume, err = client.GetEventVolume(ctx, project.Id, mixpanel.RegionUS, mixpanel.EventVolumeDays)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if volume != 5 {
+		t.Errorf("expected 5 events, got %d", volume)
+	}
+}
+
+func TestClientImportEvents(t *testing.T) {
+	client, server := newTestClient(t)
+	ctx := context.Background()
+
+	project := server.AddProject(mixpanel.Project{Name: "test"})
+	client = client.WithAuthenticator(&mixpanel.ProjectSecretAuthenticator{Secret: project.Secret})
+
+	// Spread over two batches, with an invalid event in the second one
+	events := make([]mixpanel.Event, mixpanel.ImportBatchSize+2)
+	for i := range events {
+		events[i] = mixpanel.Event{
+			Event: "Seed",
+			Properties: map[string]interface{}{
+				"time":        1700000000,
+				"distinct_id": "user",
+				"$insert_id":  fmt.Sprintf("seed-%d", i),
+			},
+		}
+	}
+	delete(events[mixpanel.ImportBatchSize+1].Properties, "time")
+
+	imported, err := client.ImportEvents(ctx, project.Id, mixpanel.RegionUS, events, true)
+
+	var importErr *mixpanel.ImportError
+	if !errors.As(err, &importErr) {
+		t.Fatalf("expected an ImportError, got %v", err)
+	}
+	if len(importErr.FailedRecords) != 1 || importErr.FailedRecords[0].Index != mixpanel.ImportBatchSize+1 || importErr.FailedRecords[0].Field != "properties.time" {
+		t.Errorf("unexpected failed records: %+v", importErr.FailedRecords)
+	}
+	if imported != int64(len(events)-1) {
+		t.Errorf("expected %d events imported, got %d", len(events)-1, imported)
+	}
+
+	// Importing the same events again does not duplicate them
+	if _, err := client.ImportEvents(ctx, project.Id, mixpanel.RegionUS, events[:10], true); err != nil {
+		t.Fatal(err)
+	}
+	if count := len(server.Events(project.Id)); count != len(events)-1 {
+		t.Errorf("expected %d events stored, got %d", len(events)-1, count)
+	}
+}
+
+func TestClientDashboardSubscriptionChannelSwitch(t *testing.T) {
+	client, server := newTestClient(t)
+	ctx := context.Background()
+
+	project := server.AddProject(mixpanel.Project{Name: "test"})
+
+	subscription, err := client.CreateDashboardSubscription(ctx, project.Id, &mixpanel.DashboardSubscription{
+		DashboardId: 42,
+		Channel:     mixpanel.DashboardSubscriptionChannelEmail,
+		Recipients:  []string{"product@example.com"},
+		Schedule:    "0 9 * * MON",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The recipients are cleared, not left as they were
+	subscription, err = client.UpdateDashboardSubscription(ctx, project.Id, &mixpanel.DashboardSubscription{
+		Id:           subscription.Id,
+		DashboardId:  42,
+		Channel:      mixpanel.DashboardSubscriptionChannelSlack,
+		SlackChannel: "#product-metrics",
+		Schedule:     "0 9 * * MON",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(subscription.Recipients) > 0 || subscription.SlackChannel != "#product-metrics" {
+		t.Errorf("unexpected subscription after switching to Slack: %+v", subscription)
+	}
+
+	subscription, err = client.UpdateDashboardSubscription(ctx, project.Id, &mixpanel.DashboardSubscription{
+		Id:          subscription.Id,
+		DashboardId: 42,
+		Channel:     mixpanel.DashboardSubscriptionChannelEmail,
+		Recipients:  []string{"product@example.com"},
+		Schedule:    "0 9 * * MON",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if subscription.SlackChannel != "" || len(subscription.Recipients) != 1 {
+		t.Errorf("unexpected subscription after switching to email: %+v", subscription)
+	}
+}
+
+func TestClientUserProfile(t *testing.T) {
+	client, server := newTestClient(t)
+	ctx := context.Background()
+
+	project := server.AddProject(mixpanel.Project{Name: "test"})
+
+	if _, err := client.GetUserProfile(ctx, project.Id, mixpanel.RegionUS, "qa-1"); !mixpanel.IsNotFound(err) {
+		t.Fatalf("expected a NotFoundError, got %v", err)
+	}
+
+	err := client.UpdateUserProfile(ctx, mixpanel.RegionUS, project.Token, "qa-1", mixpanel.ProfileUpdate{
+		Set:     map[string]interface{}{"role": "qa", "seats": 3},
+		SetOnce: map[string]interface{}{"created": "2024-01-01"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = client.UpdateUserProfile(ctx, mixpanel.RegionUS, project.Token, "qa-1", mixpanel.ProfileUpdate{
+		SetOnce: map[string]interface{}{"created": "2024-06-01"},
+		Unset:   []string{"seats"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	properties, err := client.GetUserProfile(ctx, project.Id, mixpanel.RegionUS, "qa-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]interface{}{"role": "qa", "created": "2024-01-01"}
+	if !reflect.DeepEqual(properties, expected) {
+		t.Errorf("expected %v, got %v", expected, properties)
+	}
+
+	err = client.UpdateUserProfile(ctx, mixpanel.RegionUS, "unknown", "qa-1", mixpanel.ProfileUpdate{Unset: []string{"role"}})
+	var validationErr *mixpanel.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Errorf("expected a ValidationError, got %v", err)
+	}
+}
+
+func TestClientGroupProfile(t *testing.T) {
+	client, server := newTestClient(t)
+	ctx := context.Background()
+
+	project := server.AddProject(mixpanel.Project{Name: "test"})
+
+	groupKey, err := client.CreateGroupKey(ctx, project.Id, &mixpanel.GroupKey{PropertyName: "company_id", DisplayName: "Company"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := client.GetGroupKeyByPropertyName(ctx, project.Id, "account_id"); !mixpanel.IsNotFound(err) {
+		t.Fatalf("expected a NotFoundError, got %v", err)
+	}
+
+	found, err := client.GetGroupKeyByPropertyName(ctx, project.Id, "company_id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found.Id != groupKey.Id {
+		t.Errorf("expected group key %d, got %d", groupKey.Id, found.Id)
+	}
+
+	if _, err := client.GetGroupProfile(ctx, project.Id, mixpanel.RegionUS, groupKey.Id, "acme"); !mixpanel.IsNotFound(err) {
+		t.Fatalf("expected a NotFoundError, got %v", err)
+	}
+
+	err = client.UpdateGroupProfile(ctx, mixpanel.RegionUS, project.Token, "company_id", "acme", mixpanel.ProfileUpdate{
+		Set: map[string]interface{}{"plan": "enterprise", "seats": 50},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = client.UpdateGroupProfile(ctx, mixpanel.RegionUS, project.Token, "company_id", "acme", mixpanel.ProfileUpdate{
+		Unset: []string{"seats"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	properties, err := client.GetGroupProfile(ctx, project.Id, mixpanel.RegionUS, groupKey.Id, "acme")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]interface{}{"plan": "enterprise"}
+	if !reflect.DeepEqual(properties, expected) {
+		t.Errorf("expected %v, got %v", expected, properties)
+	}
+
+	// Group profiles do not leak into user profiles with the same ID
+	if _, err := client.GetUserProfile(ctx, project.Id, mixpanel.RegionUS, "acme"); !mixpanel.IsNotFound(err) {
+		t.Errorf("expected a NotFoundError, got %v", err)
+	}
+}
@@ -0,0 +1,137 @@
+package mixpanel
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Delivery channels supported by board subscriptions.
+const DashboardSubscriptionChannelEmail = "email"
+const DashboardSubscriptionChannelSlack = "slack"
+
+type DashboardSubscription struct {
+	Id           int64    `json:"id,omitempty"`
+	DashboardId  int64    `json:"dashboard_id"`
+	Channel      string   `json:"channel"`
+	Recipients   []string `json:"recipients,omitempty"`
+	SlackChannel string   `json:"slack_channel,omitempty"`
+	Schedule     string   `json:"schedule"`
+	Timezone     string   `json:"timezone,omitempty"`
+}
+
+// dashboardSubscriptionUpdate is the body of a PATCH. The delivery fields are
+// always sent, so that switching channel clears the one no longer used.
+type dashboardSubscriptionUpdate struct {
+	Channel      string   `json:"channel"`
+	Recipients   []string `json:"recipients"`
+	SlackChannel string   `json:"slack_channel"`
+	Schedule     string   `json:"schedule"`
+	Timezone     string   `json:"timezone,omitempty"`
+}
+
+type DashboardSubscriptionResponse struct {
+	Status  string                `json:"status"`
+	Results DashboardSubscription `json:"results"`
+}
+
+func (c *Client) GetDashboardSubscription(ctx context.Context, projectId, dashboardId, id int64) (*DashboardSubscription, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/api/app/projects/%d/dashboards/%d/subscriptions/%d", c.HostURL, projectId, dashboardId, id), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var response DashboardSubscriptionResponse
+	err = json.Unmarshal(body, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.Results, nil
+}
+
+func (c *Client) CreateDashboardSubscription(ctx context.Context, projectId int64, subscription *DashboardSubscription) (*DashboardSubscription, error) {
+	payload, err := json.Marshal(subscription)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/api/app/projects/%d/dashboards/%d/subscriptions", c.HostURL, projectId, subscription.DashboardId), bytes.NewBuffer(payload))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", "application/json")
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var response DashboardSubscriptionResponse
+	err = json.Unmarshal(body, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.Results, nil
+}
+
+func (c *Client) UpdateDashboardSubscription(ctx context.Context, projectId int64, subscription *DashboardSubscription) (*DashboardSubscription, error) {
+	update := dashboardSubscriptionUpdate{
+		Channel:      subscription.Channel,
+		Recipients:   subscription.Recipients,
+		SlackChannel: subscription.SlackChannel,
+		Schedule:     subscription.Schedule,
+		Timezone:     subscription.Timezone,
+	}
+	if update.Recipients == nil {
+		update.Recipients = []string{}
+	}
+
+	payload, err := json.Marshal(update)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "PATCH", fmt.Sprintf("%s/api/app/projects/%d/dashboards/%d/subscriptions/%d", c.HostURL, projectId, subscription.DashboardId, subscription.Id), bytes.NewBuffer(payload))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", "application/json")
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var response DashboardSubscriptionResponse
+	err = json.Unmarshal(body, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.Results, nil
+}
+
+func (c *Client) DeleteDashboardSubscription(ctx context.Context, projectId, dashboardId, id int64) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/api/app/projects/%d/dashboards/%d/subscriptions/%d", c.HostURL, projectId, dashboardId, id), nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.doRequest(req)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,128 @@
+package mixpanel
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// Operators supported by drop filter conditions.
+const DropFilterOperatorEquals = "equals"
+const DropFilterOperatorNotEquals = "not_equals"
+const DropFilterOperatorContains = "contains"
+const DropFilterOperatorNotContains = "not_contains"
+const DropFilterOperatorIsSet = "is_set"
+const DropFilterOperatorIsNotSet = "is_not_set"
+
+// DropFilter blocks matching events at ingestion. When Properties is empty
+// the whole event is dropped, otherwise only the listed properties are
+// stripped from it.
+type DropFilter struct {
+	Id               int64                 `json:"id,omitempty"`
+	EventNamePattern string                `json:"event_name_pattern"`
+	Conditions       []DropFilterCondition `json:"conditions"`
+	Properties       []string              `json:"properties"`
+	Description      string                `json:"description"`
+	Enabled          bool                  `json:"enabled"`
+}
+
+type DropFilterCondition struct {
+	Property string `json:"property"`
+	Operator string `json:"operator"`
+	Value    string `json:"value,omitempty"`
+}
+
+type DropFilterResponse struct {
+	Status  string     `json:"status"`
+	Results DropFilter `json:"results"`
+}
+
+func (c *Client) GetDropFilter(ctx context.Context, projectId, id int64) (*DropFilter, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/api/app/projects/%d/drop-filters/%d", c.HostURL, projectId, id), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var response DropFilterResponse
+	err = json.Unmarshal(body, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.Results, nil
+}
+
+func (c *Client) CreateDropFilter(ctx context.Context, projectId int64, filter *DropFilter) (*DropFilter, error) {
+	payload, err := json.Marshal(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/api/app/projects/%d/drop-filters", c.HostURL, projectId), bytes.NewBuffer(payload))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", "application/json")
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var response DropFilterResponse
+	err = json.Unmarshal(body, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.Results, nil
+}
+
+func (c *Client) UpdateDropFilter(ctx context.Context, projectId int64, filter *DropFilter) (*DropFilter, error) {
+	payload, err := json.Marshal(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "PATCH", fmt.Sprintf("%s/api/app/projects/%d/drop-filters/%d", c.HostURL, projectId, filter.Id), bytes.NewBuffer(payload))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", "application/json")
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var response DropFilterResponse
+	err = json.Unmarshal(body, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.Results, nil
+}
+
+func (c *Client) DeleteDropFilter(ctx context.Context, projectId, id int64) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/api/app/projects/%d/drop-filters/%d", c.HostURL, projectId, id), nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.doRequest(req)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package mixpanel
+
+// Data residency regions a project can be hosted in.
+const RegionUS = "US"
+const RegionEU = "EU"
+const RegionIN = "IN"
+
+// Endpoints holds the base URLs of each Mixpanel API family for a region.
+type Endpoints struct {
+	// App is the host of the app (management) API.
+	App string
+	// Ingestion is the host of the /track, /engage and /import APIs.
+	Ingestion string
+	// Export is the host of the raw event export API.
+	Export string
+	// Query is the host of the query API.
+	Query string
+}
+
+var regionEndpoints = map[string]Endpoints{
+	RegionUS: {
+		App:       "https://mixpanel.com",
+		Ingestion: "https://api.mixpanel.com",
+		Export:    "https://data.mixpanel.com",
+		Query:     "https://mixpanel.com",
+	},
+	RegionEU: {
+		App:       "https://eu.mixpanel.com",
+		Ingestion: "https://api-eu.mixpanel.com",
+		Export:    "https://data-eu.mixpanel.com",
+		Query:     "https://eu.mixpanel.com",
+	},
+	RegionIN: {
+		App:       "https://in.mixpanel.com",
+		Ingestion: "https://api-in.mixpanel.com",
+		Export:    "https://data-in.mixpanel.com",
+		Query:     "https://in.mixpanel.com",
+	},
+}
+
+// Endpoints returns the hosts to use for a project in the given region,
+// falling back to the US hosts for unknown regions. When APIHost is set on
+// the client, every API family is sent to it instead.
+func (c *Client) Endpoints(region string) Endpoints {
+	if c.APIHost != "" {
+		return Endpoints{
+			App:       c.APIHost,
+			Ingestion: c.APIHost,
+			Export:    c.APIHost,
+			Query:     c.APIHost,
+		}
+	}
+
+	endpoints, ok := regionEndpoints[region]
+	if !ok {
+		return regionEndpoints[RegionUS]
+	}
+
+	return endpoints
+}
+
+// RegionEndpoints returns the public hosts of a region, ignoring any
+// APIHost override, e.g. to build links to the Mixpanel app.
+func RegionEndpoints(region string) (Endpoints, bool) {
+	endpoints, ok := regionEndpoints[region]
+	return endpoints, ok
+}
+
+// RegionFromDomain maps the domain reported in the project metadata to a region.
+func RegionFromDomain(domain string) string {
+	switch domain {
+	case "eu.mixpanel.com":
+		return RegionEU
+	case "in.mixpanel.com":
+		return RegionIN
+	default:
+		return RegionUS
+	}
+}
@@ -0,0 +1,167 @@
+package mixpanel
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// ProfileUpdate holds the operations applied to a profile. Empty operations
+// are not sent.
+type ProfileUpdate struct {
+	// Set overwrites properties.
+	Set map[string]interface{}
+	// SetOnce sets properties that do not exist yet.
+	SetOnce map[string]interface{}
+	// Unset removes properties.
+	Unset []string
+}
+
+type engageResponse struct {
+	Status int    `json:"status"`
+	Error  string `json:"error"`
+}
+
+type engageQueryResponse struct {
+	Results []struct {
+		DistinctId string                 `json:"$distinct_id"`
+		Properties map[string]interface{} `json:"$properties"`
+	} `json:"results"`
+}
+
+// UpdateUserProfile applies update to the profile of distinctId, in the
+// project of region identified by token. The client must authenticate with
+// the project secret.
+func (c *Client) UpdateUserProfile(ctx context.Context, region, token, distinctId string, update ProfileUpdate) error {
+	return c.updateProfile(ctx, region, "/engage", map[string]interface{}{
+		"$token":       token,
+		"$distinct_id": distinctId,
+	}, update)
+}
+
+// GetUserProfile returns the properties of the profile of distinctId through
+// the query API, or a NotFoundError when it does not exist.
+func (c *Client) GetUserProfile(ctx context.Context, projectId int64, region, distinctId string) (map[string]interface{}, error) {
+	form := url.Values{}
+	form.Set("distinct_id", distinctId)
+
+	return c.queryProfile(ctx, projectId, region, form, distinctId)
+}
+
+// UpdateGroupProfile applies update to the profile of the group groupId of
+// groupKey, the property name of a group key of the project. The client must
+// authenticate with the project secret.
+func (c *Client) UpdateGroupProfile(ctx context.Context, region, token, groupKey, groupId string, update ProfileUpdate) error {
+	return c.updateProfile(ctx, region, "/groups", map[string]interface{}{
+		"$token":     token,
+		"$group_key": groupKey,
+		"$group_id":  groupId,
+	}, update)
+}
+
+// GetGroupProfile returns the properties of the profile of the group groupId
+// of the group key dataGroupId, or a NotFoundError when it does not exist.
+func (c *Client) GetGroupProfile(ctx context.Context, projectId int64, region string, dataGroupId int64, groupId string) (map[string]interface{}, error) {
+	form := url.Values{}
+	form.Set("data_group_id", strconv.FormatInt(dataGroupId, 10))
+	form.Set("distinct_id", groupId)
+
+	return c.queryProfile(ctx, projectId, region, form, groupId)
+}
+
+// updateProfile sends one object per operation of update to an Engage
+// endpoint, each made of target and the operation.
+func (c *Client) updateProfile(ctx context.Context, region, path string, target map[string]interface{}, update ProfileUpdate) error {
+	var objects []map[string]interface{}
+	addOperation := func(operation string, value interface{}) {
+		object := map[string]interface{}{operation: value}
+		for key, value := range target {
+			object[key] = value
+		}
+		objects = append(objects, object)
+	}
+
+	if len(update.Set) > 0 {
+		addOperation("$set", update.Set)
+	}
+	if len(update.SetOnce) > 0 {
+		addOperation("$set_once", update.SetOnce)
+	}
+	if len(update.Unset) > 0 {
+		addOperation("$unset", update.Unset)
+	}
+
+	if len(objects) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(objects)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s%s?verbose=1", c.Endpoints(region).Ingestion, path), bytes.NewBuffer(payload))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Add("Content-Type", "application/json")
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return err
+	}
+
+	// Rejected updates are reported with a 200 status code
+	var response engageResponse
+	err = json.Unmarshal(body, &response)
+	if err != nil {
+		return err
+	}
+
+	if response.Status != 1 {
+		return &ValidationError{&APIError{StatusCode: http.StatusOK, Message: response.Error, Body: body}}
+	}
+
+	return nil
+}
+
+// queryProfile returns the properties of the single profile matching form,
+// named id in the errors.
+func (c *Client) queryProfile(ctx context.Context, projectId int64, region string, form url.Values, id string) (map[string]interface{}, error) {
+	query := url.Values{}
+	query.Set("project_id", strconv.FormatInt(projectId, 10))
+
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/api/query/engage?%s", c.Endpoints(region).Query, query.Encode()), bytes.NewBufferString(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var response engageQueryResponse
+	err = json.Unmarshal(body, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(response.Results) == 0 {
+		return nil, &NotFoundError{&APIError{StatusCode: http.StatusNotFound, Message: fmt.Sprintf("profile %s not found", id)}}
+	}
+
+	properties := response.Results[0].Properties
+	if properties == nil {
+		properties = map[string]interface{}{}
+	}
+
+	return properties, nil
+}
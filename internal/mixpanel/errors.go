@@ -0,0 +1,95 @@
+package mixpanel
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// APIError is a non-2xx response of the Mixpanel API. Requests fail with one
+// of the more specific errors below when the status code has a meaning for
+// the caller.
+type APIError struct {
+	StatusCode int
+	// Message is the `error` field of the response, or the raw body when the
+	// response is not a Mixpanel error document.
+	Message string
+	// Request is the `request` field of the response, the path Mixpanel
+	// attributes the error to, when present.
+	Request string
+	// Body is the raw response, for the endpoints returning more details
+	// than the error message.
+	Body []byte
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("status: %d, error: %s", e.StatusCode, e.Message)
+}
+
+// NotFoundError is returned when the requested object does not exist, e.g.
+// because it was deleted outside of Terraform.
+type NotFoundError struct{ *APIError }
+
+// PermissionError is returned when the credentials are rejected or lack the
+// permission for the request.
+type PermissionError struct{ *APIError }
+
+// RateLimitError is returned when the request was still rate limited after
+// the retries of the client.
+type RateLimitError struct {
+	*APIError
+	// RetryAfter is the time Mixpanel asked to wait, zero when unknown.
+	RetryAfter time.Duration
+}
+
+// ValidationError is returned when Mixpanel rejects the request payload.
+type ValidationError struct{ *APIError }
+
+// The specific errors unwrap to their APIError, for the callers only
+// interested in the status code or the message.
+func (e *NotFoundError) Unwrap() error   { return e.APIError }
+func (e *PermissionError) Unwrap() error { return e.APIError }
+func (e *RateLimitError) Unwrap() error  { return e.APIError }
+func (e *ValidationError) Unwrap() error { return e.APIError }
+
+// IsNotFound reports whether err, or an error it wraps, is a NotFoundError.
+func IsNotFound(err error) bool {
+	var notFound *NotFoundError
+	return errors.As(err, &notFound)
+}
+
+type errorResponse struct {
+	Status  string `json:"status"`
+	Error   string `json:"error"`
+	Request string `json:"request"`
+}
+
+func newAPIError(res *http.Response, body []byte) error {
+	apiErr := &APIError{
+		StatusCode: res.StatusCode,
+		Message:    string(body),
+		Body:       body,
+	}
+
+	var response errorResponse
+	if json.Unmarshal(body, &response) == nil && response.Error != "" {
+		apiErr.Message = response.Error
+		apiErr.Request = response.Request
+	}
+
+	switch res.StatusCode {
+	case http.StatusNotFound:
+		return &NotFoundError{apiErr}
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return &PermissionError{apiErr}
+	case http.StatusTooManyRequests:
+		wait, _ := retryAfter(res)
+		return &RateLimitError{APIError: apiErr, RetryAfter: wait}
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return &ValidationError{apiErr}
+	default:
+		return apiErr
+	}
+}
@@ -0,0 +1,63 @@
+package mixpanel
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewAPIError(t *testing.T) {
+	tests := []struct {
+		statusCode int
+		expected   interface{}
+	}{
+		{http.StatusNotFound, &NotFoundError{}},
+		{http.StatusUnauthorized, &PermissionError{}},
+		{http.StatusForbidden, &PermissionError{}},
+		{http.StatusTooManyRequests, &RateLimitError{}},
+		{http.StatusBadRequest, &ValidationError{}},
+		{http.StatusUnprocessableEntity, &ValidationError{}},
+		{http.StatusInternalServerError, &APIError{}},
+		{http.StatusConflict, &APIError{}},
+	}
+
+	for _, test := range tests {
+		t.Run(http.StatusText(test.statusCode), func(t *testing.T) {
+			res := &http.Response{StatusCode: test.statusCode, Header: http.Header{}}
+			err := newAPIError(res, []byte(`{"status": "error", "error": "something went wrong", "request": "/api/app/me"}`))
+
+			if reflect.TypeOf(err) != reflect.TypeOf(test.expected) {
+				t.Fatalf("expected a %T, got %T", test.expected, err)
+			}
+
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("expected %T to unwrap to an APIError", err)
+			}
+			if apiErr.StatusCode != test.statusCode || apiErr.Message != "something went wrong" || apiErr.Request != "/api/app/me" {
+				t.Errorf("unexpected error fields %+v", apiErr)
+			}
+			if IsNotFound(err) != (test.statusCode == http.StatusNotFound) {
+				t.Errorf("unexpected IsNotFound %v", IsNotFound(err))
+			}
+		})
+	}
+}
+
+func TestNewAPIErrorDetails(t *testing.T) {
+	// Bodies that are not Mixpanel error documents are kept as the message
+	res := &http.Response{StatusCode: http.StatusBadGateway, Header: http.Header{}}
+	err := newAPIError(res, []byte("<html>Bad Gateway</html>"))
+	if err.Error() != "status: 502, error: <html>Bad Gateway</html>" {
+		t.Errorf("unexpected error %q", err)
+	}
+
+	// The wait asked by Mixpanel is kept on rate limit errors
+	res = &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{"Retry-After": []string{"120"}}}
+	var rateLimitErr *RateLimitError
+	if !errors.As(newAPIError(res, nil), &rateLimitErr) || rateLimitErr.RetryAfter != 2*time.Minute {
+		t.Errorf("expected a RateLimitError waiting 2m, got %v", rateLimitErr)
+	}
+}
@@ -0,0 +1,110 @@
+package mixpanel
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// Types of requests supported by the GDPR API.
+const GdprRequestTypeDeletion = "deletion"
+const GdprRequestTypeRetrieval = "retrieval"
+
+// Regulations a request can be filed under.
+const GdprComplianceTypeGdpr = "GDPR"
+const GdprComplianceTypeCcpa = "CCPA"
+
+// Task statuses reported by the GDPR API. Any other status means the task
+// is still in progress.
+const GdprRequestStatusSuccess = "SUCCESS"
+const GdprRequestStatusFailure = "FAILURE"
+
+type GdprRequest struct {
+	TaskId         string   `json:"task_id"`
+	Type           string   `json:"-"`
+	DistinctIds    []string `json:"distinct_ids"`
+	ComplianceType string   `json:"compliance_type"`
+	Status         string   `json:"status"`
+	Result         string   `json:"result,omitempty"`
+}
+
+type gdprCreateResponse struct {
+	Status  string `json:"status"`
+	Results struct {
+		TaskId string `json:"task_id"`
+	} `json:"results"`
+}
+
+type gdprStatusResponse struct {
+	Status  string      `json:"status"`
+	Results GdprRequest `json:"results"`
+}
+
+func gdprPath(requestType string) string {
+	if requestType == GdprRequestTypeRetrieval {
+		return "data-retrievals"
+	}
+	return "data-deletions"
+}
+
+// CreateGdprRequest submits a deletion or retrieval request for the project
+// identified by its region and token and returns the created task.
+func (c *Client) CreateGdprRequest(ctx context.Context, region, projectToken string, request *GdprRequest) (*GdprRequest, error) {
+	data := map[string]interface{}{
+		"distinct_ids":    request.DistinctIds,
+		"compliance_type": request.ComplianceType,
+	}
+
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/api/app/%s/v3.0/?token=%s", c.Endpoints(region).App, gdprPath(request.Type), url.QueryEscape(projectToken)), bytes.NewBuffer(payload))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", "application/json")
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var response gdprCreateResponse
+	err = json.Unmarshal(body, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	created := *request
+	created.TaskId = response.Results.TaskId
+	return &created, nil
+}
+
+func (c *Client) GetGdprRequest(ctx context.Context, region, projectToken, requestType, taskId string) (*GdprRequest, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/api/app/%s/v3.0/%s?token=%s", c.Endpoints(region).App, gdprPath(requestType), url.PathEscape(taskId), url.QueryEscape(projectToken)), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var response gdprStatusResponse
+	err = json.Unmarshal(body, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	request := response.Results
+	request.TaskId = taskId
+	request.Type = requestType
+	return &request, nil
+}
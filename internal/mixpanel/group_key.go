@@ -0,0 +1,148 @@
+package mixpanel
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// GroupKey is a group analytics identifier, called a data group in the
+// Mixpanel API.
+type GroupKey struct {
+	Id           int64  `json:"data_group_id,omitempty"`
+	PropertyName string `json:"property_name"`
+	DisplayName  string `json:"display_name"`
+}
+
+type GroupKeyResponse struct {
+	Status  string   `json:"status"`
+	Results GroupKey `json:"results"`
+}
+
+type GroupKeysResponse struct {
+	Status  string     `json:"status"`
+	Results []GroupKey `json:"results"`
+}
+
+func (c *Client) GetGroupKeys(ctx context.Context, projectId int64) ([]GroupKey, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/api/app/projects/%d/data-groups/", c.HostURL, projectId), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var response GroupKeysResponse
+	err = json.Unmarshal(body, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Results, nil
+}
+
+func (c *Client) GetGroupKey(ctx context.Context, projectId, id int64) (*GroupKey, error) {
+	// There is no endpoint to fetch a single data group
+	groupKeys, err := c.GetGroupKeys(ctx, projectId)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, groupKey := range groupKeys {
+		if groupKey.Id == id {
+			return &groupKey, nil
+		}
+	}
+
+	return nil, &NotFoundError{&APIError{
+		StatusCode: http.StatusNotFound,
+		Message:    fmt.Sprintf("group key not found: %d", id),
+	}}
+}
+
+// GetGroupKeyByPropertyName returns the group key of a property, or a
+// NotFoundError when the property is not a group key of the project.
+func (c *Client) GetGroupKeyByPropertyName(ctx context.Context, projectId int64, propertyName string) (*GroupKey, error) {
+	groupKeys, err := c.GetGroupKeys(ctx, projectId)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, groupKey := range groupKeys {
+		if groupKey.PropertyName == propertyName {
+			return &groupKey, nil
+		}
+	}
+
+	return nil, &NotFoundError{&APIError{
+		StatusCode: http.StatusNotFound,
+		Message:    fmt.Sprintf("group key not found: %s", propertyName),
+	}}
+}
+
+func (c *Client) CreateGroupKey(ctx context.Context, projectId int64, groupKey *GroupKey) (*GroupKey, error) {
+	payload, err := json.Marshal(groupKey)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/api/app/projects/%d/data-groups/", c.HostURL, projectId), bytes.NewBuffer(payload))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", "application/json")
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var response GroupKeyResponse
+	err = json.Unmarshal(body, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.Results, nil
+}
+
+func (c *Client) UpdateGroupKeyDisplayName(ctx context.Context, projectId, id int64, displayName string) error {
+	payload, err := json.Marshal(map[string]string{"display_name": displayName})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "PATCH", fmt.Sprintf("%s/api/app/projects/%d/data-groups/%d/", c.HostURL, projectId, id), bytes.NewBuffer(payload))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Add("Content-Type", "application/json")
+
+	_, err = c.doRequest(req)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *Client) DeleteGroupKey(ctx context.Context, projectId, id int64) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/api/app/projects/%d/data-groups/%d/", c.HostURL, projectId, id), nil)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.doRequest(req)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
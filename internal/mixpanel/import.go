@@ -0,0 +1,128 @@
+package mixpanel
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// Maximum number of events the /import API accepts in a request.
+const ImportBatchSize = 2000
+
+// Event is an event sent to the ingestion API.
+type Event struct {
+	Event      string                 `json:"event"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+// ImportFailedRecord is an event rejected by the /import API in strict mode.
+type ImportFailedRecord struct {
+	// Index is the position of the event in the events passed to
+	// ImportEvents.
+	Index    int    `json:"index"`
+	InsertId string `json:"$insert_id"`
+	Field    string `json:"field"`
+	Message  string `json:"message"`
+}
+
+// ImportError is returned when events fail the strict mode validation of
+// the /import API. The other events of the batches are still imported.
+type ImportError struct {
+	*ValidationError
+	FailedRecords []ImportFailedRecord
+}
+
+type importResponse struct {
+	Code               int                  `json:"code"`
+	Status             string               `json:"status"`
+	Error              string               `json:"error"`
+	NumRecordsImported int64                `json:"num_records_imported"`
+	FailedRecords      []ImportFailedRecord `json:"failed_records"`
+}
+
+// ImportEvents sends events to a project in region through the /import API,
+// in batches of ImportBatchSize, and returns the number of events imported.
+// The client must authenticate with the project secret. Events already
+// imported with the same $insert_id are deduplicated by Mixpanel.
+func (c *Client) ImportEvents(ctx context.Context, projectId int64, region string, events []Event, strict bool) (int64, error) {
+	var imported int64
+	var failedRecords []ImportFailedRecord
+	var validationErr *ValidationError
+
+	for offset := 0; offset < len(events); offset += ImportBatchSize {
+		end := offset + ImportBatchSize
+		if end > len(events) {
+			end = len(events)
+		}
+
+		response, err := c.importBatch(ctx, projectId, region, events[offset:end], strict)
+		if err != nil {
+			var batchErr *ValidationError
+			if !errors.As(err, &batchErr) || len(response.FailedRecords) == 0 {
+				return imported, err
+			}
+
+			// Keep going, the valid events of the batch were imported
+			validationErr = batchErr
+			for _, record := range response.FailedRecords {
+				record.Index += offset
+				failedRecords = append(failedRecords, record)
+			}
+		}
+
+		imported += response.NumRecordsImported
+	}
+
+	logInfo(ctx, "Imported events", map[string]interface{}{"project_id": projectId, "imported": imported, "failed": len(failedRecords)})
+
+	if validationErr != nil {
+		return imported, &ImportError{ValidationError: validationErr, FailedRecords: failedRecords}
+	}
+
+	return imported, nil
+}
+
+// importBatch sends a single batch. The response is decoded from the error
+// body when the batch fails validation.
+func (c *Client) importBatch(ctx context.Context, projectId int64, region string, events []Event, strict bool) (importResponse, error) {
+	var response importResponse
+
+	payload, err := json.Marshal(events)
+	if err != nil {
+		return response, err
+	}
+
+	query := url.Values{}
+	query.Set("project_id", strconv.FormatInt(projectId, 10))
+	if strict {
+		query.Set("strict", "1")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/import?%s", c.Endpoints(region).Ingestion, query.Encode()), bytes.NewBuffer(payload))
+	if err != nil {
+		return response, err
+	}
+
+	req.Header.Add("Content-Type", "application/json")
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		var validationErr *ValidationError
+		if errors.As(err, &validationErr) {
+			_ = json.Unmarshal(validationErr.Body, &response)
+		}
+		return response, err
+	}
+
+	err = json.Unmarshal(body, &response)
+	if err != nil {
+		return response, err
+	}
+
+	return response, nil
+}
@@ -0,0 +1,30 @@
+package mixpanel
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// Longest $insert_id accepted by the ingestion API.
+const InsertIdMaxLength = 36
+
+var insertIdRegexp = regexp.MustCompile(`^[A-Za-z0-9-]+$`)
+
+// ValidateInsertId checks an $insert_id against the constraints of the
+// ingestion API. Events with an invalid $insert_id are rejected by /import
+// and are not deduplicated by /track.
+func ValidateInsertId(id string) error {
+	if id == "" {
+		return fmt.Errorf("$insert_id must not be empty")
+	}
+
+	if len(id) > InsertIdMaxLength {
+		return fmt.Errorf("$insert_id must be at most %d bytes, got %d", InsertIdMaxLength, len(id))
+	}
+
+	if !insertIdRegexp.MatchString(id) {
+		return fmt.Errorf("$insert_id must only contain alphanumeric characters and dashes, got: %q", id)
+	}
+
+	return nil
+}
@@ -0,0 +1,107 @@
+package mixpanel
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Subsystem of the provider logs written by the client.
+const logSubsystem = "mixpanel"
+
+// LogLevelEnv sets the level of the client logs. HTTP requests are only
+// logged when it is set.
+const LogLevelEnv = "TF_LOG_PROVIDER_MIXPANEL"
+
+// Query parameters and log fields never written as is.
+var redactedKeys = []string{"token", "secret", "api_secret", "api_key", "password"}
+
+const redacted = "REDACTED"
+
+func logContext(ctx context.Context) context.Context {
+	ctx = tflog.NewSubsystem(ctx, logSubsystem, tflog.WithLevelFromEnv(LogLevelEnv))
+	return tflog.SubsystemMaskFieldValuesWithFieldKeys(ctx, logSubsystem, redactedKeys...)
+}
+
+func logDebug(ctx context.Context, msg string, fields map[string]interface{}) {
+	tflog.SubsystemDebug(logContext(ctx), logSubsystem, msg, fields)
+}
+
+func logInfo(ctx context.Context, msg string, fields map[string]interface{}) {
+	tflog.SubsystemInfo(logContext(ctx), logSubsystem, msg, fields)
+}
+
+type attemptKey struct{}
+
+// withAttemptCounter lets the logging transport number the attempts the
+// retry client makes for a single request.
+func withAttemptCounter(ctx context.Context) context.Context {
+	return context.WithValue(ctx, attemptKey{}, new(int32))
+}
+
+// loggingTransport logs every HTTP attempt, including the retried ones. The
+// Authorization header is never logged and credentials are redacted from the
+// URL.
+type loggingTransport struct {
+	next http.RoundTripper
+}
+
+func newLoggingTransport(next http.RoundTripper) *loggingTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &loggingTransport{next: next}
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	attempt := int32(1)
+	if counter, ok := ctx.Value(attemptKey{}).(*int32); ok {
+		attempt = atomic.AddInt32(counter, 1)
+	}
+
+	fields := map[string]interface{}{
+		"method":  req.Method,
+		"url":     redactURL(req.URL),
+		"attempt": attempt,
+	}
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	fields["latency_ms"] = time.Since(start).Milliseconds()
+
+	if err != nil {
+		fields["error"] = strings.ReplaceAll(err.Error(), req.URL.String(), redactURL(req.URL))
+		logDebug(ctx, "HTTP request failed", fields)
+		return resp, err
+	}
+
+	fields["status"] = resp.StatusCode
+	logDebug(ctx, "HTTP request", fields)
+
+	return resp, nil
+}
+
+func redactURL(u *url.URL) string {
+	clone := *u
+	if clone.User != nil {
+		clone.User = url.User(redacted)
+	}
+
+	query := clone.Query()
+	for _, key := range redactedKeys {
+		if query.Has(key) {
+			query.Set(key, redacted)
+		}
+	}
+	clone.RawQuery = query.Encode()
+
+	return clone.String()
+}
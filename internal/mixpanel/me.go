@@ -1,9 +1,12 @@
 package mixpanel
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"sort"
+	"strconv"
 )
 
 type MeResponse struct {
@@ -13,6 +16,7 @@ type MeResponse struct {
 
 type MeResults struct {
 	Organizations map[string]Organization `json:"organizations"`
+	Projects      map[string]MeProject    `json:"projects"`
 }
 
 type Organization struct {
@@ -20,11 +24,91 @@ type Organization struct {
 	Name string `json:"name"`
 }
 
-func (c *Client) GetOrganizations() ([]Organization, error) {
-	fmt.Printf("%s", c.HostURL)
+type MeProject struct {
+	Name           string `json:"name"`
+	OrganizationId int64  `json:"organization_id"`
+	Domain         string `json:"domain"`
+	Timezone       string `json:"timezone_name"`
+}
+
+// ProjectSummary is a project as listed for the authenticated account,
+// without its credentials.
+type ProjectSummary struct {
+	Id             int64
+	Name           string
+	OrganizationId int64
+	Domain         string
+	Timezone       string
+}
+
+// GetOrganizations returns the organizations of the authenticated account.
+// The list is cached by the client.
+func (c *Client) GetOrganizations(ctx context.Context) ([]Organization, error) {
+	organizations, err := c.cached(ctx, "organizations", func(ctx context.Context) (interface{}, error) {
+		return c.fetchOrganizations(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	orgSlice, ok := organizations.([]Organization)
+	if !ok {
+		return nil, fmt.Errorf("unexpected cached organizations: %T", organizations)
+	}
+
+	return orgSlice, nil
+}
+
+func (c *Client) fetchOrganizations(ctx context.Context) ([]Organization, error) {
+	me, err := c.getMe(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var orgSlice []Organization
+	for _, value := range me.Organizations {
+		orgSlice = append(orgSlice, value)
+	}
+	sort.Slice(orgSlice, func(i, j int) bool { return orgSlice[i].Id < orgSlice[j].Id })
+
+	logDebug(ctx, "Fetched organizations", map[string]interface{}{"count": len(orgSlice)})
+
+	return orgSlice, nil
+}
+
+// GetProjects returns the projects the authenticated account has access to,
+// across organizations, ordered by ID. The list is not cached as projects
+// may be created during the same run.
+func (c *Client) GetProjects(ctx context.Context) ([]ProjectSummary, error) {
+	me, err := c.getMe(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	projects := make([]ProjectSummary, 0, len(me.Projects))
+	for key, value := range me.Projects {
+		// The ID is only given as the key
+		id, err := strconv.ParseInt(key, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse project id: %s", key)
+		}
 
+		projects = append(projects, ProjectSummary{
+			Id:             id,
+			Name:           value.Name,
+			OrganizationId: value.OrganizationId,
+			Domain:         RegionFromDomain(value.Domain),
+			Timezone:       value.Timezone,
+		})
+	}
+	sort.Slice(projects, func(i, j int) bool { return projects[i].Id < projects[j].Id })
+
+	return projects, nil
+}
+
+func (c *Client) getMe(ctx context.Context) (*MeResults, error) {
 	// Not querying the workspace users is a lot faster
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/api/app/me?include_workspace_users=false", c.HostURL), nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/api/app/me?include_workspace_users=false", c.HostURL), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -40,10 +124,5 @@ func (c *Client) GetOrganizations() ([]Organization, error) {
 		return nil, err
 	}
 
-	var orgSlice []Organization
-	for _, value := range response.Results.Organizations {
-		orgSlice = append(orgSlice, value)
-	}
-
-	return orgSlice, nil
+	return &response.Results, nil
 }
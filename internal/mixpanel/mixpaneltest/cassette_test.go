@@ -0,0 +1,117 @@
+package mixpaneltest
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"terraform-provider-mixpanel/internal/mixpanel"
+)
+
+func TestCassetteScrubsSecrets(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	project := server.AddProject(mixpanel.Project{Name: "test"})
+
+	t.Setenv(RecordEnv, "1")
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	cassette := NewCassette(t, path)
+	client := cassette.Wrap(&http.Client{})
+
+	do := func(method, url, contentType, body string) {
+		t.Helper()
+		req, err := http.NewRequest(method, url, strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.SetBasicAuth("user", "password")
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("%s %s: unexpected status code %d", method, url, resp.StatusCode)
+		}
+	}
+
+	do("GET", fmt.Sprintf("%s/settings/project/%d/metadata?token=%s", server.URL, project.Id, project.Token), "", "")
+	do("POST", server.URL+"/engage?verbose=1", "application/json",
+		fmt.Sprintf(`[{"$token":%q,"$distinct_id":"user-1","$set":{"$email":"user@example.com","$name":"User"}}]`, project.Token))
+	do("POST", fmt.Sprintf("%s/api/query/engage?project_id=%d", server.URL, project.Id), "application/x-www-form-urlencoded", "distinct_id=user-1")
+
+	if err := cassette.save(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, secret := range []string{project.Token, project.Secret, project.ApiKey, "password", "user@example.com"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("cassette contains %q:\n%s", secret, data)
+		}
+	}
+	if !strings.Contains(string(data), "\\\"name\\\":\\\"test\\\"") {
+		t.Errorf("cassette lost the project name:\n%s", data)
+	}
+	if !strings.Contains(string(data), "\\\"$name\\\":\\\"User\\\"") {
+		t.Errorf("cassette lost the profile name:\n%s", data)
+	}
+}
+
+func TestSanitizeBody(t *testing.T) {
+	tests := map[string]struct {
+		contentType string
+		body        string
+		expected    string
+	}{
+		"me": {
+			contentType: "application/json",
+			body:        `{"status":"ok","results":{"user_id":1,"user_email":"user@example.com","user_name":"User"}}`,
+			expected:    `{"results":{"user_email":"REDACTED","user_id":1,"user_name":"User"},"status":"ok"}`,
+		},
+		"engage update": {
+			contentType: "application/json",
+			body:        `[{"$token":"abc","$distinct_id":"user-1","$set":{"$email":"user@example.com","plan":"free"}}]`,
+			expected:    `[{"$distinct_id":"user-1","$set":{"$email":"REDACTED","plan":"free"},"$token":"REDACTED"}]`,
+		},
+		"project": {
+			contentType: "application/json",
+			body:        `{"api_key":"a","token":"b","secret":"c","api_secret":"d","name":"test"}`,
+			expected:    `{"api_key":"REDACTED","api_secret":"REDACTED","name":"test","secret":"REDACTED","token":"REDACTED"}`,
+		},
+		"form": {
+			contentType: "application/x-www-form-urlencoded",
+			body:        "distinct_id=user-1&project_token=abc",
+			expected:    "distinct_id=user-1&project_token=REDACTED",
+		},
+		"not sensitive": {
+			contentType: "application/json",
+			body:        `{"data_group_id":1,"group_key":"company","emails_sent":2,"tokens":["a"]}`,
+			expected:    `{"data_group_id":1,"emails_sent":2,"group_key":"company","tokens":["a"]}`,
+		},
+		"text": {
+			contentType: "text/plain",
+			body:        "token=abc",
+			expected:    "token=abc",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if sanitized := sanitizeBody(test.contentType, []byte(test.body)); sanitized != test.expected {
+				t.Errorf("expected %s, got %s", test.expected, sanitized)
+			}
+		})
+	}
+}
@@ -0,0 +1,1241 @@
+// Package mixpaneltest provides an in-process fake of the Mixpanel API, so
+// the client and the provider can be tested without credentials or network.
+package mixpaneltest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"terraform-provider-mixpanel/internal/mixpanel"
+)
+
+// Default reference data of a new server.
+var DefaultOrganization = mixpanel.Organization{Id: 1, Name: "Test Organization"}
+
+var DefaultTimezones = []mixpanel.Timezone{
+	{Id: 1, Name: "UTC"},
+	{Id: 2, Name: "US/Pacific"},
+	{Id: 3, Name: "Europe/Paris"},
+	{Id: 4, Name: "Asia/Kolkata"},
+}
+
+// Domains the API reports for each cluster of a new project.
+var clusterDomains = map[int64]string{
+	mixpanel.MixpanelUsClusterId: "mixpanel.com",
+	mixpanel.MixpanelEuClusterId: "eu.mixpanel.com",
+	mixpanel.MixpanelInClusterId: "in.mixpanel.com",
+}
+
+// Request is a request received by the server.
+type Request struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Header http.Header
+	Body   []byte
+}
+
+// Failure makes the server answer matching requests with an error instead
+// of handling them.
+type Failure struct {
+	// Method matches any method when empty.
+	Method string
+	// Path is a prefix of the matched paths.
+	Path       string
+	StatusCode int
+	// RetryAfter is sent in the Retry-After header when set.
+	RetryAfter time.Duration
+	// Times is the number of requests to fail, every matching request fails
+	// when zero.
+	Times int
+}
+
+// Event is an event ingested by the server.
+type Event struct {
+	Name       string
+	Properties map[string]interface{}
+}
+
+// Server is a fake Mixpanel API. Every API host of a client can point to it,
+// e.g. through mixpanel.Client.APIHost or the api_host provider attribute.
+type Server struct {
+	*httptest.Server
+
+	// GdprStatus is the status reported for GDPR requests. Default is SUCCESS.
+	GdprStatus string
+	// GdprPendingChecks is the number of status checks of each GDPR request
+	// answered with PENDING before GdprStatus is reported.
+	GdprPendingChecks int
+
+	mu            sync.Mutex
+	nextId        int64
+	organizations []mixpanel.Organization
+	timezones     []mixpanel.Timezone
+	projects      map[int64]*mixpanel.Project
+	projectOrgs   map[int64]int64
+	groupKeys     map[int64][]mixpanel.GroupKey
+	dropFilters   map[int64]map[int64]*mixpanel.DropFilter
+	subscriptions map[int64]map[int64]*mixpanel.DashboardSubscription
+	sessionReplay map[int64]*mixpanel.SessionReplaySettings
+	gdprRequests  map[string]*mixpanel.GdprRequest
+	gdprChecks    map[string]int
+	events        map[int64][]Event
+	profiles      map[int64]map[string]map[string]interface{}
+	groupProfiles map[int64]map[string]map[string]interface{}
+	requests      []Request
+	failures      []*Failure
+	routes        []route
+}
+
+type route struct {
+	method  string
+	pattern *regexp.Regexp
+	handler func(w http.ResponseWriter, r *http.Request, body []byte, args []int64)
+}
+
+// NewServer starts a fake Mixpanel API with a single organization and a few
+// timezones. The caller should call Close when finished.
+func NewServer() *Server {
+	s := &Server{
+		GdprStatus:    mixpanel.GdprRequestStatusSuccess,
+		nextId:        1000,
+		organizations: []mixpanel.Organization{DefaultOrganization},
+		timezones:     append([]mixpanel.Timezone(nil), DefaultTimezones...),
+		projects:      make(map[int64]*mixpanel.Project),
+		projectOrgs:   make(map[int64]int64),
+		groupKeys:     make(map[int64][]mixpanel.GroupKey),
+		dropFilters:   make(map[int64]map[int64]*mixpanel.DropFilter),
+		subscriptions: make(map[int64]map[int64]*mixpanel.DashboardSubscription),
+		sessionReplay: make(map[int64]*mixpanel.SessionReplaySettings),
+		gdprRequests:  make(map[string]*mixpanel.GdprRequest),
+		gdprChecks:    make(map[string]int),
+		events:        make(map[int64][]Event),
+		profiles:      make(map[int64]map[string]map[string]interface{}),
+		groupProfiles: make(map[int64]map[string]map[string]interface{}),
+	}
+
+	s.routes = []route{
+		{"GET", regexp.MustCompile(`^/api/app/me$`), s.getMe},
+		{"GET", regexp.MustCompile(`^/api/app/timezones$`), s.getTimezones},
+		{"GET", regexp.MustCompile(`^/settings/project/(\d+)/metadata$`), s.getProject},
+		{"POST", regexp.MustCompile(`^/api/app/organizations/(\d+)/create-project$`), s.createProject},
+		{"POST", regexp.MustCompile(`^/projects/update/(\d+)$`), s.updateProject},
+		{"POST", regexp.MustCompile(`^/api/app/projects/(\d+)/reset-secret$`), s.resetProjectSecret},
+		{"GET", regexp.MustCompile(`^/api/app/projects/(\d+)/data-groups/$`), s.getGroupKeys},
+		{"POST", regexp.MustCompile(`^/api/app/projects/(\d+)/data-groups/$`), s.createGroupKey},
+		{"PATCH", regexp.MustCompile(`^/api/app/projects/(\d+)/data-groups/(\d+)/$`), s.updateGroupKey},
+		{"DELETE", regexp.MustCompile(`^/api/app/projects/(\d+)/data-groups/(\d+)/$`), s.deleteGroupKey},
+		{"POST", regexp.MustCompile(`^/api/app/projects/(\d+)/drop-filters$`), s.createDropFilter},
+		{"GET", regexp.MustCompile(`^/api/app/projects/(\d+)/drop-filters/(\d+)$`), s.getDropFilter},
+		{"PATCH", regexp.MustCompile(`^/api/app/projects/(\d+)/drop-filters/(\d+)$`), s.updateDropFilter},
+		{"DELETE", regexp.MustCompile(`^/api/app/projects/(\d+)/drop-filters/(\d+)$`), s.deleteDropFilter},
+		{"POST", regexp.MustCompile(`^/api/app/projects/(\d+)/dashboards/(\d+)/subscriptions$`), s.createSubscription},
+		{"GET", regexp.MustCompile(`^/api/app/projects/(\d+)/dashboards/(\d+)/subscriptions/(\d+)$`), s.getSubscription},
+		{"PATCH", regexp.MustCompile(`^/api/app/projects/(\d+)/dashboards/(\d+)/subscriptions/(\d+)$`), s.updateSubscription},
+		{"DELETE", regexp.MustCompile(`^/api/app/projects/(\d+)/dashboards/(\d+)/subscriptions/(\d+)$`), s.deleteSubscription},
+		{"GET", regexp.MustCompile(`^/api/app/projects/(\d+)/session-replay/settings$`), s.getSessionReplay},
+		{"PATCH", regexp.MustCompile(`^/api/app/projects/(\d+)/session-replay/settings$`), s.updateSessionReplay},
+		{"POST", regexp.MustCompile(`^/api/app/data-(?:deletions|retrievals)/v3\.0/$`), s.createGdprRequest},
+		{"GET", regexp.MustCompile(`^/api/app/data-(?:deletions|retrievals)/v3\.0/[^/]+$`), s.getGdprRequest},
+		{"POST", regexp.MustCompile(`^/import$`), s.importEvents},
+		{"POST", regexp.MustCompile(`^/engage$`), s.updateUserProfiles},
+		{"POST", regexp.MustCompile(`^/groups$`), s.updateGroupProfiles},
+		{"POST", regexp.MustCompile(`^/api/query/engage$`), s.queryProfiles},
+		{"GET", regexp.MustCompile(`^/api/query/events/names$`), s.getEventNames},
+		{"GET", regexp.MustCompile(`^/api/query/events$`), s.getEventCounts},
+	}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+
+	return s
+}
+
+// AddOrganization gives the account access to another organization.
+func (s *Server) AddOrganization(organization mixpanel.Organization) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.organizations = append(s.organizations, organization)
+}
+
+// AddProject stores a project in the default organization as if it was
+// created in Mixpanel and returns it with its generated ID and credentials.
+func (s *Server) AddProject(project mixpanel.Project) mixpanel.Project {
+	return s.AddOrganizationProject(DefaultOrganization.Id, project)
+}
+
+// AddOrganizationProject is AddProject for a given organization.
+func (s *Server) AddOrganizationProject(organizationId int64, project mixpanel.Project) mixpanel.Project {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return *s.addProject(organizationId, project)
+}
+
+func (s *Server) addProject(organizationId int64, project mixpanel.Project) *mixpanel.Project {
+	s.nextId++
+	project.Id = s.nextId
+	if project.Domain == "" {
+		project.Domain = clusterDomains[mixpanel.MixpanelUsClusterId]
+	}
+	if project.Timezone == "" {
+		project.Timezone = s.timezones[0].Name
+	}
+	project.ApiKey = fmt.Sprintf("api-key-%d", project.Id)
+	project.Token = fmt.Sprintf("token-%d", project.Id)
+	project.Secret = fmt.Sprintf("secret-%d", project.Id)
+
+	s.projects[project.Id] = &project
+	s.projectOrgs[project.Id] = organizationId
+	return &project
+}
+
+// Project returns a project as stored by the server. Its domain is the one
+// reported by the API, e.g. eu.mixpanel.com.
+func (s *Server) Project(id int64) (mixpanel.Project, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	project, ok := s.projects[id]
+	if !ok {
+		return mixpanel.Project{}, false
+	}
+	return *project, true
+}
+
+// DeleteProject removes a project, as if it was deleted outside of Terraform.
+func (s *Server) DeleteProject(id int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.projects, id)
+	delete(s.projectOrgs, id)
+}
+
+// ProjectIds returns the IDs of the stored projects, in ascending order.
+func (s *Server) ProjectIds() []int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids := make([]int64, 0, len(s.projects))
+	for id := range s.projects {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// GroupKeys returns the group keys of a project as stored by the server.
+func (s *Server) GroupKeys(projectId int64) []mixpanel.GroupKey {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]mixpanel.GroupKey(nil), s.groupKeys[projectId]...)
+}
+
+// DeleteGroupKey removes a group key, as if it was deleted outside of
+// Terraform.
+func (s *Server) DeleteGroupKey(projectId, id int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	groupKeys := s.groupKeys[projectId]
+	for i, groupKey := range groupKeys {
+		if groupKey.Id == id {
+			s.groupKeys[projectId] = append(groupKeys[:i:i], groupKeys[i+1:]...)
+			return
+		}
+	}
+}
+
+// DropFilter returns a drop filter as stored by the server.
+func (s *Server) DropFilter(projectId, id int64) (mixpanel.DropFilter, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	filter, ok := s.dropFilters[projectId][id]
+	if !ok {
+		return mixpanel.DropFilter{}, false
+	}
+	return *filter, true
+}
+
+// DeleteDropFilter removes a drop filter, as if it was deleted outside of
+// Terraform.
+func (s *Server) DeleteDropFilter(projectId, id int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.dropFilters[projectId], id)
+}
+
+// SessionReplaySettings returns the session replay settings of a project
+// as stored by the server, or false when they were never updated.
+func (s *Server) SessionReplaySettings(projectId int64) (mixpanel.SessionReplaySettings, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	settings, ok := s.sessionReplay[projectId]
+	if !ok {
+		return mixpanel.SessionReplaySettings{}, false
+	}
+	return *settings, true
+}
+
+// DashboardSubscription returns a board subscription as stored by the server.
+func (s *Server) DashboardSubscription(projectId, id int64) (mixpanel.DashboardSubscription, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	subscription, ok := s.subscriptions[projectId][id]
+	if !ok {
+		return mixpanel.DashboardSubscription{}, false
+	}
+	return *subscription, true
+}
+
+// DeleteGdprRequest removes a GDPR request, as if Mixpanel purged its task.
+func (s *Server) DeleteGdprRequest(taskId string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.gdprRequests, taskId)
+}
+
+// DeleteDashboardSubscription removes a board subscription, as if it was
+// deleted outside of Terraform.
+func (s *Server) DeleteDashboardSubscription(projectId, id int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.subscriptions[projectId], id)
+}
+
+// AddEvents ingests count events with the given name into a project, as if
+// they were sent to Mixpanel.
+func (s *Server) AddEvents(projectId int64, name string, count int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := 0; i < count; i++ {
+		s.events[projectId] = append(s.events[projectId], Event{Name: name, Properties: map[string]interface{}{}})
+	}
+}
+
+// Events returns the events ingested into a project, in ingestion order.
+func (s *Server) Events(projectId int64) []Event {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Event(nil), s.events[projectId]...)
+}
+
+// UserProfile returns the properties of a user profile, as set through the
+// Engage API or SetUserProfile.
+func (s *Server) UserProfile(projectId int64, distinctId string) (map[string]interface{}, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	profile, ok := s.profiles[projectId][distinctId]
+	if !ok {
+		return nil, false
+	}
+
+	properties := make(map[string]interface{}, len(profile))
+	for key, value := range profile {
+		properties[key] = value
+	}
+	return properties, true
+}
+
+// SetUserProfile replaces the properties of a user profile, as if it was
+// updated outside of Terraform.
+func (s *Server) SetUserProfile(projectId int64, distinctId string, properties map[string]interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.profiles[projectId] == nil {
+		s.profiles[projectId] = make(map[string]map[string]interface{})
+	}
+	s.profiles[projectId][distinctId] = properties
+}
+
+// GroupProfile returns the properties of the profile of a group, identified
+// by the property name of its group key and its ID.
+func (s *Server) GroupProfile(projectId int64, groupKey, groupId string) (map[string]interface{}, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	profile, ok := s.groupProfiles[projectId][groupProfileKey(groupKey, groupId)]
+	if !ok {
+		return nil, false
+	}
+
+	properties := make(map[string]interface{}, len(profile))
+	for key, value := range profile {
+		properties[key] = value
+	}
+	return properties, true
+}
+
+// SetGroupProfile replaces the properties of the profile of a group, as if it
+// was updated outside of Terraform.
+func (s *Server) SetGroupProfile(projectId int64, groupKey, groupId string, properties map[string]interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.groupProfiles[projectId] == nil {
+		s.groupProfiles[projectId] = make(map[string]map[string]interface{})
+	}
+	s.groupProfiles[projectId][groupProfileKey(groupKey, groupId)] = properties
+}
+
+func groupProfileKey(groupKey, groupId string) string {
+	return groupKey + "/" + groupId
+}
+
+// Fail registers a failure. Failures are matched in registration order.
+func (s *Server) Fail(failure Failure) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failures = append(s.failures, &failure)
+}
+
+// Requests returns the requests received so far, including failed ones.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Request(nil), s.requests...)
+}
+
+// RequestCount returns the number of requests received for a method and
+// path.
+func (s *Server) RequestCount(method, path string) int {
+	count := 0
+	for _, request := range s.Requests() {
+		if request.Method == method && request.Path == path {
+			count++
+		}
+	}
+	return count
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, Request{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Query:  r.URL.Query(),
+		Header: r.Header.Clone(),
+		Body:   body,
+	})
+
+	if _, _, ok := r.BasicAuth(); !ok && !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ") {
+		writeError(w, http.StatusUnauthorized, "missing credentials")
+		return
+	}
+
+	if s.fail(w, r) {
+		return
+	}
+
+	for _, route := range s.routes {
+		if route.method != r.Method {
+			continue
+		}
+
+		match := route.pattern.FindStringSubmatch(r.URL.Path)
+		if match == nil {
+			continue
+		}
+
+		args := make([]int64, 0, len(match)-1)
+		for _, arg := range match[1:] {
+			id, _ := strconv.ParseInt(arg, 10, 64)
+			args = append(args, id)
+		}
+
+		route.handler(w, r, body, args)
+		return
+	}
+
+	writeError(w, http.StatusNotFound, fmt.Sprintf("no route for %s %s", r.Method, r.URL.Path))
+}
+
+func (s *Server) fail(w http.ResponseWriter, r *http.Request) bool {
+	for i, failure := range s.failures {
+		if failure.Method != "" && failure.Method != r.Method {
+			continue
+		}
+		if !strings.HasPrefix(r.URL.Path, failure.Path) {
+			continue
+		}
+
+		if failure.Times > 0 {
+			failure.Times--
+			if failure.Times == 0 {
+				s.failures = append(s.failures[:i], s.failures[i+1:]...)
+			}
+		}
+
+		if failure.RetryAfter > 0 {
+			w.Header().Set("Retry-After", strconv.FormatInt(int64(failure.RetryAfter/time.Second), 10))
+		}
+		writeError(w, failure.StatusCode, http.StatusText(failure.StatusCode))
+		return true
+	}
+
+	return false
+}
+
+func writeJSON(w http.ResponseWriter, results interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"status":  "ok",
+		"results": results,
+	})
+}
+
+func writeError(w http.ResponseWriter, statusCode int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"status": "error",
+		"error":  message,
+	})
+}
+
+func (s *Server) getMe(w http.ResponseWriter, _ *http.Request, _ []byte, _ []int64) {
+	organizations := make(map[string]mixpanel.Organization)
+	for _, organization := range s.organizations {
+		organizations[strconv.FormatInt(organization.Id, 10)] = organization
+	}
+
+	projects := make(map[string]mixpanel.MeProject)
+	for id, project := range s.projects {
+		projects[strconv.FormatInt(id, 10)] = mixpanel.MeProject{
+			Name:           project.Name,
+			OrganizationId: s.projectOrgs[id],
+			Domain:         project.Domain,
+			Timezone:       project.Timezone,
+		}
+	}
+
+	writeJSON(w, map[string]interface{}{"organizations": organizations, "projects": projects})
+}
+
+func (s *Server) getTimezones(w http.ResponseWriter, _ *http.Request, _ []byte, _ []int64) {
+	results := make([][]interface{}, 0, len(s.timezones))
+	for _, timezone := range s.timezones {
+		results = append(results, []interface{}{timezone.Id, timezone.Name})
+	}
+
+	writeJSON(w, results)
+}
+
+func (s *Server) project(w http.ResponseWriter, id int64) (*mixpanel.Project, bool) {
+	project, ok := s.projects[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("project %d not found", id))
+	}
+	return project, ok
+}
+
+func (s *Server) getProject(w http.ResponseWriter, _ *http.Request, _ []byte, args []int64) {
+	project, ok := s.project(w, args[0])
+	if !ok {
+		return
+	}
+
+	writeJSON(w, project)
+}
+
+func (s *Server) createProject(w http.ResponseWriter, _ *http.Request, body []byte, args []int64) {
+	found := false
+	for _, organization := range s.organizations {
+		found = found || organization.Id == args[0]
+	}
+	if !found {
+		writeError(w, http.StatusForbidden, fmt.Sprintf("no access to organization %d", args[0]))
+		return
+	}
+
+	var data struct {
+		Name       string `json:"project_name"`
+		ClusterId  int64  `json:"cluster_id"`
+		TimezoneId int64  `json:"timezone_id"`
+	}
+	if err := json.Unmarshal(body, &data); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	domain, ok := clusterDomains[data.ClusterId]
+	if !ok {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid cluster_id: %d", data.ClusterId))
+		return
+	}
+
+	timezone := ""
+	for _, tz := range s.timezones {
+		if tz.Id == data.TimezoneId {
+			timezone = tz.Name
+		}
+	}
+	if timezone == "" {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid timezone_id: %d", data.TimezoneId))
+		return
+	}
+
+	project := s.addProject(args[0], mixpanel.Project{
+		Name:     data.Name,
+		Domain:   domain,
+		Timezone: timezone,
+	})
+
+	writeJSON(w, project)
+}
+
+func (s *Server) updateProject(w http.ResponseWriter, _ *http.Request, body []byte, args []int64) {
+	project, ok := s.project(w, args[0])
+	if !ok {
+		return
+	}
+
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if form.Has("name") {
+		project.Name = form.Get("name")
+	}
+	if form.Has("timezone_name") {
+		project.Timezone = form.Get("timezone_name")
+	}
+
+	writeJSON(w, project)
+}
+
+func (s *Server) resetProjectSecret(w http.ResponseWriter, _ *http.Request, _ []byte, args []int64) {
+	project, ok := s.project(w, args[0])
+	if !ok {
+		return
+	}
+
+	s.nextId++
+	project.Secret = fmt.Sprintf("secret-%d-%d", project.Id, s.nextId)
+
+	writeJSON(w, map[string]string{"secret": project.Secret})
+}
+
+func (s *Server) getGroupKeys(w http.ResponseWriter, _ *http.Request, _ []byte, args []int64) {
+	if _, ok := s.project(w, args[0]); !ok {
+		return
+	}
+
+	writeJSON(w, append([]mixpanel.GroupKey{}, s.groupKeys[args[0]]...))
+}
+
+func (s *Server) createGroupKey(w http.ResponseWriter, _ *http.Request, body []byte, args []int64) {
+	if _, ok := s.project(w, args[0]); !ok {
+		return
+	}
+
+	var groupKey mixpanel.GroupKey
+	if err := json.Unmarshal(body, &groupKey); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.nextId++
+	groupKey.Id = s.nextId
+	s.groupKeys[args[0]] = append(s.groupKeys[args[0]], groupKey)
+
+	writeJSON(w, groupKey)
+}
+
+func (s *Server) updateGroupKey(w http.ResponseWriter, _ *http.Request, body []byte, args []int64) {
+	var data mixpanel.GroupKey
+	if err := json.Unmarshal(body, &data); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	for i, groupKey := range s.groupKeys[args[0]] {
+		if groupKey.Id == args[1] {
+			s.groupKeys[args[0]][i].DisplayName = data.DisplayName
+			writeJSON(w, s.groupKeys[args[0]][i])
+			return
+		}
+	}
+
+	writeError(w, http.StatusNotFound, fmt.Sprintf("data group %d not found", args[1]))
+}
+
+func (s *Server) deleteGroupKey(w http.ResponseWriter, _ *http.Request, _ []byte, args []int64) {
+	groupKeys := s.groupKeys[args[0]]
+	for i, groupKey := range groupKeys {
+		if groupKey.Id == args[1] {
+			s.groupKeys[args[0]] = append(groupKeys[:i], groupKeys[i+1:]...)
+			writeJSON(w, nil)
+			return
+		}
+	}
+
+	writeError(w, http.StatusNotFound, fmt.Sprintf("data group %d not found", args[1]))
+}
+
+func (s *Server) createDropFilter(w http.ResponseWriter, _ *http.Request, body []byte, args []int64) {
+	if _, ok := s.project(w, args[0]); !ok {
+		return
+	}
+
+	var filter mixpanel.DropFilter
+	if err := json.Unmarshal(body, &filter); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.nextId++
+	filter.Id = s.nextId
+	if s.dropFilters[args[0]] == nil {
+		s.dropFilters[args[0]] = make(map[int64]*mixpanel.DropFilter)
+	}
+	s.dropFilters[args[0]][filter.Id] = &filter
+
+	writeJSON(w, filter)
+}
+
+func (s *Server) dropFilter(w http.ResponseWriter, args []int64) (*mixpanel.DropFilter, bool) {
+	filter, ok := s.dropFilters[args[0]][args[1]]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("drop filter %d not found", args[1]))
+	}
+	return filter, ok
+}
+
+func (s *Server) getDropFilter(w http.ResponseWriter, _ *http.Request, _ []byte, args []int64) {
+	if filter, ok := s.dropFilter(w, args); ok {
+		writeJSON(w, filter)
+	}
+}
+
+func (s *Server) updateDropFilter(w http.ResponseWriter, _ *http.Request, body []byte, args []int64) {
+	filter, ok := s.dropFilter(w, args)
+	if !ok {
+		return
+	}
+
+	var data mixpanel.DropFilter
+	if err := json.Unmarshal(body, &data); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	data.Id = filter.Id
+	*filter = data
+
+	writeJSON(w, filter)
+}
+
+func (s *Server) deleteDropFilter(w http.ResponseWriter, _ *http.Request, _ []byte, args []int64) {
+	if _, ok := s.dropFilter(w, args); ok {
+		delete(s.dropFilters[args[0]], args[1])
+		writeJSON(w, nil)
+	}
+}
+
+func (s *Server) createSubscription(w http.ResponseWriter, _ *http.Request, body []byte, args []int64) {
+	project, ok := s.project(w, args[0])
+	if !ok {
+		return
+	}
+
+	var subscription mixpanel.DashboardSubscription
+	if err := json.Unmarshal(body, &subscription); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.nextId++
+	subscription.Id = s.nextId
+	subscription.DashboardId = args[1]
+	if subscription.Timezone == "" {
+		subscription.Timezone = project.Timezone
+	}
+	if s.subscriptions[args[0]] == nil {
+		s.subscriptions[args[0]] = make(map[int64]*mixpanel.DashboardSubscription)
+	}
+	s.subscriptions[args[0]][subscription.Id] = &subscription
+
+	writeJSON(w, subscription)
+}
+
+func (s *Server) subscription(w http.ResponseWriter, args []int64) (*mixpanel.DashboardSubscription, bool) {
+	subscription, ok := s.subscriptions[args[0]][args[2]]
+	if !ok || subscription.DashboardId != args[1] {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("subscription %d not found", args[2]))
+		return nil, false
+	}
+	return subscription, true
+}
+
+func (s *Server) getSubscription(w http.ResponseWriter, _ *http.Request, _ []byte, args []int64) {
+	if subscription, ok := s.subscription(w, args); ok {
+		writeJSON(w, subscription)
+	}
+}
+
+func (s *Server) updateSubscription(w http.ResponseWriter, _ *http.Request, body []byte, args []int64) {
+	subscription, ok := s.subscription(w, args)
+	if !ok {
+		return
+	}
+
+	// Fields missing from the body are left as they are
+	data := *subscription
+	if err := json.Unmarshal(body, &data); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	data.Id = subscription.Id
+	data.DashboardId = subscription.DashboardId
+	*subscription = data
+
+	writeJSON(w, subscription)
+}
+
+func (s *Server) deleteSubscription(w http.ResponseWriter, _ *http.Request, _ []byte, args []int64) {
+	if _, ok := s.subscription(w, args); ok {
+		delete(s.subscriptions[args[0]], args[2])
+		writeJSON(w, nil)
+	}
+}
+
+func (s *Server) getSessionReplay(w http.ResponseWriter, _ *http.Request, _ []byte, args []int64) {
+	if _, ok := s.project(w, args[0]); !ok {
+		return
+	}
+
+	settings, ok := s.sessionReplay[args[0]]
+	if !ok {
+		settings = &mixpanel.SessionReplaySettings{MaskAllText: true, MaskAllInputs: true, RetentionDays: 30}
+	}
+
+	writeJSON(w, settings)
+}
+
+func (s *Server) updateSessionReplay(w http.ResponseWriter, _ *http.Request, body []byte, args []int64) {
+	if _, ok := s.project(w, args[0]); !ok {
+		return
+	}
+
+	var settings mixpanel.SessionReplaySettings
+	if err := json.Unmarshal(body, &settings); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	s.sessionReplay[args[0]] = &settings
+
+	writeJSON(w, settings)
+}
+
+func (s *Server) projectByToken(w http.ResponseWriter, r *http.Request) bool {
+	token := r.URL.Query().Get("token")
+	for _, project := range s.projects {
+		if project.Token == token {
+			return true
+		}
+	}
+
+	writeError(w, http.StatusNotFound, "unknown project token")
+	return false
+}
+
+func (s *Server) createGdprRequest(w http.ResponseWriter, r *http.Request, body []byte, _ []int64) {
+	if !s.projectByToken(w, r) {
+		return
+	}
+
+	var request mixpanel.GdprRequest
+	if err := json.Unmarshal(body, &request); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.nextId++
+	request.TaskId = fmt.Sprintf("task-%d", s.nextId)
+	s.gdprRequests[request.TaskId] = &request
+
+	writeJSON(w, map[string]string{"task_id": request.TaskId})
+}
+
+func (s *Server) getGdprRequest(w http.ResponseWriter, r *http.Request, _ []byte, _ []int64) {
+	if !s.projectByToken(w, r) {
+		return
+	}
+
+	taskId := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+	request, ok := s.gdprRequests[taskId]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("task %s not found", taskId))
+		return
+	}
+
+	result := *request
+	result.Status = s.GdprStatus
+	s.gdprChecks[taskId]++
+	if s.gdprChecks[taskId] <= s.GdprPendingChecks {
+		result.Status = "PENDING"
+	}
+	writeJSON(w, result)
+}
+
+// queryProject returns the project of the project_id query parameter.
+func (s *Server) queryProject(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	projectId, err := strconv.ParseInt(r.URL.Query().Get("project_id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid project_id")
+		return 0, false
+	}
+
+	_, ok := s.project(w, projectId)
+	return projectId, ok
+}
+
+// The query API answers with bare documents, without status nor results.
+func writeQueryJSON(w http.ResponseWriter, document interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(document)
+}
+
+func (s *Server) getEventNames(w http.ResponseWriter, r *http.Request, _ []byte, _ []int64) {
+	projectId, ok := s.queryProject(w, r)
+	if !ok {
+		return
+	}
+
+	seen := make(map[string]bool)
+	names := []string{}
+	for _, event := range s.events[projectId] {
+		if !seen[event.Name] {
+			seen[event.Name] = true
+			names = append(names, event.Name)
+		}
+	}
+
+	writeQueryJSON(w, names)
+}
+
+// getEventCounts reports every event of the project as ingested today.
+func (s *Server) getEventCounts(w http.ResponseWriter, r *http.Request, _ []byte, _ []int64) {
+	projectId, ok := s.queryProject(w, r)
+	if !ok {
+		return
+	}
+
+	var names []string
+	if err := json.Unmarshal([]byte(r.URL.Query().Get("event")), &names); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid event")
+		return
+	}
+
+	today := time.Now().UTC().Format("2006-01-02")
+	values := make(map[string]map[string]int64)
+	for _, name := range names {
+		values[name] = map[string]int64{today: 0}
+	}
+	for _, event := range s.events[projectId] {
+		if counts, ok := values[event.Name]; ok {
+			counts[today]++
+		}
+	}
+
+	writeQueryJSON(w, map[string]interface{}{
+		"data": map[string]interface{}{
+			"series": []string{today},
+			"values": values,
+		},
+		"legend_size": len(values),
+	})
+}
+
+// importEvents ingests events authenticated with the project secret. In
+// strict mode the invalid events are reported, the others are imported.
+// Events with the $insert_id of an imported event are counted but not
+// stored again.
+func (s *Server) importEvents(w http.ResponseWriter, r *http.Request, body []byte, _ []int64) {
+	projectId, ok := s.queryProject(w, r)
+	if !ok {
+		return
+	}
+
+	if username, _, _ := r.BasicAuth(); username != s.projects[projectId].Secret {
+		writeError(w, http.StatusUnauthorized, "invalid project secret")
+		return
+	}
+
+	var events []mixpanel.Event
+	if err := json.Unmarshal(body, &events); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	imported := make(map[string]bool)
+	for _, event := range s.events[projectId] {
+		if insertId, ok := event.Properties["$insert_id"].(string); ok {
+			imported[insertId] = true
+		}
+	}
+
+	var failedRecords []mixpanel.ImportFailedRecord
+	var count int64
+	for i, event := range events {
+		insertId, _ := event.Properties["$insert_id"].(string)
+		if field, message := validateEvent(event); field != "" {
+			failedRecords = append(failedRecords, mixpanel.ImportFailedRecord{Index: i, InsertId: insertId, Field: field, Message: message})
+			continue
+		}
+
+		count++
+		if insertId != "" && imported[insertId] {
+			continue
+		}
+		imported[insertId] = insertId != ""
+		s.events[projectId] = append(s.events[projectId], Event{Name: event.Event, Properties: event.Properties})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if len(failedRecords) > 0 && r.URL.Query().Get("strict") == "1" {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"code":                 http.StatusBadRequest,
+			"status":               "Bad Request",
+			"error":                "some data points in the request failed validation",
+			"num_records_imported": count,
+			"failed_records":       failedRecords,
+		})
+		return
+	}
+
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"code":                 http.StatusOK,
+		"status":               "OK",
+		"num_records_imported": count,
+	})
+}
+
+// validateEvent returns the first invalid field of an event and why.
+func validateEvent(event mixpanel.Event) (string, string) {
+	if event.Event == "" {
+		return "event", "'event' must not be empty"
+	}
+	if _, ok := event.Properties["time"].(float64); !ok {
+		return "properties.time", "'properties.time' is invalid: must be specified as seconds or milliseconds since epoch"
+	}
+	if _, ok := event.Properties["distinct_id"]; !ok {
+		return "properties.distinct_id", "'properties.distinct_id' is missing"
+	}
+	if insertId, ok := event.Properties["$insert_id"]; ok {
+		if id, isString := insertId.(string); !isString || mixpanel.ValidateInsertId(id) != nil {
+			return "properties.$insert_id", "'properties.$insert_id' is invalid"
+		}
+	}
+	return "", ""
+}
+
+// projectIdByToken returns the ID of the project of a token.
+func (s *Server) projectIdByToken(token string) (int64, bool) {
+	for id, project := range s.projects {
+		if project.Token == token {
+			return id, true
+		}
+	}
+	return 0, false
+}
+
+// applyProfileUpdate applies the operation of an Engage object to profile.
+func applyProfileUpdate(profile map[string]interface{}, object map[string]interface{}) {
+	if set, ok := object["$set"].(map[string]interface{}); ok {
+		for key, value := range set {
+			profile[key] = value
+		}
+	}
+	if setOnce, ok := object["$set_once"].(map[string]interface{}); ok {
+		for key, value := range setOnce {
+			if _, exists := profile[key]; !exists {
+				profile[key] = value
+			}
+		}
+	}
+	if unset, ok := object["$unset"].([]interface{}); ok {
+		for _, key := range unset {
+			if name, isString := key.(string); isString {
+				delete(profile, name)
+			}
+		}
+	}
+}
+
+// writeEngageJSON answers an Engage update, which reports errors with a 200
+// status code.
+func writeEngageJSON(w http.ResponseWriter, message string) {
+	status := 1
+	var engageError interface{}
+	if message != "" {
+		status = 0
+		engageError = message
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"status": status, "error": engageError})
+}
+
+func (s *Server) updateUserProfiles(w http.ResponseWriter, _ *http.Request, body []byte, _ []int64) {
+	var objects []map[string]interface{}
+	if err := json.Unmarshal(body, &objects); err != nil {
+		writeEngageJSON(w, err.Error())
+		return
+	}
+
+	for _, object := range objects {
+		token, _ := object["$token"].(string)
+		projectId, ok := s.projectIdByToken(token)
+		if !ok {
+			writeEngageJSON(w, "token, missing or empty")
+			return
+		}
+
+		distinctId, _ := object["$distinct_id"].(string)
+		if distinctId == "" {
+			writeEngageJSON(w, "$distinct_id, missing or empty")
+			return
+		}
+
+		if s.profiles[projectId] == nil {
+			s.profiles[projectId] = make(map[string]map[string]interface{})
+		}
+		if s.profiles[projectId][distinctId] == nil {
+			s.profiles[projectId][distinctId] = make(map[string]interface{})
+		}
+		applyProfileUpdate(s.profiles[projectId][distinctId], object)
+	}
+
+	writeEngageJSON(w, "")
+}
+
+// queryProfiles answers Engage queries for a single distinct_id, or a single
+// group ID when data_group_id is set.
+func (s *Server) queryProfiles(w http.ResponseWriter, r *http.Request, body []byte, _ []int64) {
+	projectId, ok := s.queryProject(w, r)
+	if !ok {
+		return
+	}
+
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	profiles := s.profiles[projectId]
+	if dataGroupId := form.Get("data_group_id"); dataGroupId != "" {
+		profiles = nil
+		for _, groupKey := range s.groupKeys[projectId] {
+			if strconv.FormatInt(groupKey.Id, 10) == dataGroupId {
+				profiles = s.groupKeyProfiles(projectId, groupKey.PropertyName)
+			}
+		}
+		if profiles == nil {
+			writeError(w, http.StatusBadRequest, "unknown data_group_id")
+			return
+		}
+	}
+
+	results := []map[string]interface{}{}
+	distinctId := form.Get("distinct_id")
+	if profile, ok := profiles[distinctId]; ok {
+		results = append(results, map[string]interface{}{
+			"$distinct_id": distinctId,
+			"$properties":  profile,
+		})
+	}
+
+	writeQueryJSON(w, map[string]interface{}{
+		"page":      0,
+		"page_size": 1000,
+		"results":   results,
+		"status":    "ok",
+		"total":     len(results),
+	})
+}
+
+// groupKeyProfiles returns the profiles of the groups of a group key, by
+// group ID.
+func (s *Server) groupKeyProfiles(projectId int64, groupKey string) map[string]map[string]interface{} {
+	profiles := make(map[string]map[string]interface{})
+	for key, profile := range s.groupProfiles[projectId] {
+		if groupId, ok := strings.CutPrefix(key, groupKey+"/"); ok {
+			profiles[groupId] = profile
+		}
+	}
+	return profiles
+}
+
+func (s *Server) updateGroupProfiles(w http.ResponseWriter, _ *http.Request, body []byte, _ []int64) {
+	var objects []map[string]interface{}
+	if err := json.Unmarshal(body, &objects); err != nil {
+		writeEngageJSON(w, err.Error())
+		return
+	}
+
+	for _, object := range objects {
+		token, _ := object["$token"].(string)
+		projectId, ok := s.projectIdByToken(token)
+		if !ok {
+			writeEngageJSON(w, "token, missing or empty")
+			return
+		}
+
+		groupKey, _ := object["$group_key"].(string)
+		groupId, _ := object["$group_id"].(string)
+		if groupKey == "" || groupId == "" {
+			writeEngageJSON(w, "$group_key and $group_id, missing or empty")
+			return
+		}
+
+		key := groupProfileKey(groupKey, groupId)
+		if s.groupProfiles[projectId] == nil {
+			s.groupProfiles[projectId] = make(map[string]map[string]interface{})
+		}
+		if s.groupProfiles[projectId][key] == nil {
+			s.groupProfiles[projectId][key] = make(map[string]interface{})
+		}
+		applyProfileUpdate(s.groupProfiles[projectId][key], object)
+	}
+
+	writeEngageJSON(w, "")
+}
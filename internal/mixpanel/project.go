@@ -2,6 +2,7 @@ package mixpanel
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -12,6 +13,13 @@ import (
 // Hardcoded in the Mixpanel frontend code.
 const MixpanelUsClusterId = 1
 const MixpanelEuClusterId = 5
+const MixpanelInClusterId = 7
+
+var regionClusterIds = map[string]int64{
+	RegionUS: MixpanelUsClusterId,
+	RegionEU: MixpanelEuClusterId,
+	RegionIN: MixpanelInClusterId,
+}
 
 type Project struct {
 	Id       int64  `json:"id"`
@@ -38,8 +46,8 @@ type ProjectResponseResults struct {
 	Secret   string `json:"secret"`
 }
 
-func (c *Client) GetProject(id int64) (*Project, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/settings/project/%d/metadata", c.HostURL, id), nil)
+func (c *Client) GetProject(ctx context.Context, id int64) (*Project, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/settings/project/%d/metadata", c.HostURL, id), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -58,18 +66,13 @@ func (c *Client) GetProject(id int64) (*Project, error) {
 	project := Project{
 		Id:       response.Results.Id,
 		Name:     response.Results.Name,
+		Domain:   RegionFromDomain(response.Results.Domain),
 		Timezone: response.Results.Timezone,
 		ApiKey:   response.Results.ApiKey,
 		Token:    response.Results.Token,
 		Secret:   response.Results.Secret,
 	}
 
-	if response.Results.Domain == "eu.mixpanel.com" {
-		project.Domain = "EU"
-	} else {
-		project.Domain = "US"
-	}
-
 	return &project, nil
 }
 
@@ -79,21 +82,19 @@ type createProjectBody struct {
 	TimezoneId int64  `json:"timezone_id"`
 }
 
-func (c *Client) CreateProject(project *Project) (*Project, error) {
+func (c *Client) CreateProject(ctx context.Context, project *Project) (*Project, error) {
 
-	var clusterId int64
-	if project.Domain == "US" {
-		clusterId = MixpanelUsClusterId
-	} else {
-		clusterId = MixpanelEuClusterId
+	clusterId, ok := regionClusterIds[project.Domain]
+	if !ok {
+		return nil, fmt.Errorf("unsupported domain: %s", project.Domain)
 	}
 
-	timezoneId, err := c.GetTimezoneId(project.Timezone)
+	timezoneId, err := c.GetTimezoneId(ctx, project.Timezone)
 	if err != nil {
 		return nil, err
 	}
 
-	organization, err := c.GetOrganizations()
+	organization, err := c.GetOrganizations(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -112,7 +113,7 @@ func (c *Client) CreateProject(project *Project) (*Project, error) {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/api/app/organizations/%d/create-project", c.HostURL, organizationId), bytes.NewBuffer(payload))
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/api/app/organizations/%d/create-project", c.HostURL, organizationId), bytes.NewBuffer(payload))
 	if err != nil {
 		return nil, err
 	}
@@ -129,17 +130,16 @@ func (c *Client) CreateProject(project *Project) (*Project, error) {
 	}
 
 	project.Id = response.Results.Id
-	// log to stdout
-	fmt.Println("Project created with ID: ", project.Id)
+	logInfo(ctx, "Created project", map[string]interface{}{"project_id": project.Id})
 
 	return project, nil
 }
 
-func (c *Client) UpdateProjectName(id int64, name string) error {
+func (c *Client) UpdateProjectName(ctx context.Context, id int64, name string) error {
 	data := url.Values{}
 	data.Set("name", name)
 
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/projects/update/%d", c.HostURL, id), strings.NewReader(data.Encode()))
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/projects/update/%d", c.HostURL, id), strings.NewReader(data.Encode()))
 	if err != nil {
 		return err
 	}
@@ -155,13 +155,13 @@ func (c *Client) UpdateProjectName(id int64, name string) error {
 	return nil
 }
 
-func (c *Client) UpdateProjectTimezone(id int64, timezone string) error {
+func (c *Client) UpdateProjectTimezone(ctx context.Context, id int64, timezone string) error {
 	data := url.Values{}
 	// Don't know if there are cases where timezone_name is different from timezone
 	data.Set("timezone", timezone)
 	data.Set("timezone_name", timezone)
 
-	req, err := http.NewRequest("POST", fmt.Sprintf("%s/projects/update/%d", c.HostURL, id), strings.NewReader(data.Encode()))
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/projects/update/%d", c.HostURL, id), strings.NewReader(data.Encode()))
 	if err != nil {
 		return err
 	}
@@ -176,3 +176,34 @@ func (c *Client) UpdateProjectTimezone(id int64, timezone string) error {
 
 	return nil
 }
+
+type resetSecretResponse struct {
+	Status  string `json:"status"`
+	Results struct {
+		Secret string `json:"secret"`
+	} `json:"results"`
+}
+
+// ResetProjectSecret replaces the API secret of a project and returns the
+// new one. The previous secret stops working right away.
+func (c *Client) ResetProjectSecret(ctx context.Context, id int64) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/api/app/projects/%d/reset-secret", c.HostURL, id), nil)
+	if err != nil {
+		return "", err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return "", err
+	}
+
+	var response resetSecretResponse
+	err = json.Unmarshal(body, &response)
+	if err != nil {
+		return "", err
+	}
+
+	logInfo(ctx, "Reset project secret", map[string]interface{}{"project_id": id})
+
+	return response.Results.Secret, nil
+}
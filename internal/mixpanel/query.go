@@ -0,0 +1,102 @@
+package mixpanel
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// EventVolumeDays is the number of days, today included, counted by
+// GetEventVolume.
+const EventVolumeDays = 30
+
+// Maximum number of event names the query API returns.
+const eventNamesLimit = 255
+
+type eventsResponse struct {
+	Data struct {
+		Values map[string]map[string]int64 `json:"values"`
+	} `json:"data"`
+}
+
+// GetEventVolume returns the number of events a project in region ingested
+// over the last days, through the query API.
+func (c *Client) GetEventVolume(ctx context.Context, projectId int64, region string, days int) (int64, error) {
+	queryHost := c.Endpoints(region).Query
+
+	names, err := c.getEventNames(ctx, queryHost, projectId)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(names) == 0 {
+		return 0, nil
+	}
+
+	events, err := json.Marshal(names)
+	if err != nil {
+		return 0, err
+	}
+
+	query := url.Values{}
+	query.Set("project_id", strconv.FormatInt(projectId, 10))
+	query.Set("event", string(events))
+	query.Set("type", "general")
+	query.Set("unit", "day")
+	query.Set("interval", strconv.Itoa(days))
+
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/api/query/events?%s", queryHost, query.Encode()), nil)
+	if err != nil {
+		return 0, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return 0, err
+	}
+
+	var response eventsResponse
+	err = json.Unmarshal(body, &response)
+	if err != nil {
+		return 0, err
+	}
+
+	var volume int64
+	for _, counts := range response.Data.Values {
+		for _, count := range counts {
+			volume += count
+		}
+	}
+
+	return volume, nil
+}
+
+// getEventNames returns the names of the events a project received in the
+// last 31 days.
+func (c *Client) getEventNames(ctx context.Context, queryHost string, projectId int64) ([]string, error) {
+	query := url.Values{}
+	query.Set("project_id", strconv.FormatInt(projectId, 10))
+	query.Set("type", "general")
+	query.Set("limit", strconv.Itoa(eventNamesLimit))
+
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/api/query/events/names?%s", queryHost, query.Encode()), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	err = json.Unmarshal(body, &names)
+	if err != nil {
+		return nil, err
+	}
+
+	return names, nil
+}
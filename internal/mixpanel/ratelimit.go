@@ -0,0 +1,83 @@
+package mixpanel
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// APIFamily groups the Mixpanel endpoints sharing a rate limit.
+type APIFamily string
+
+const APIFamilyApp APIFamily = "app"
+const APIFamilyQuery APIFamily = "query"
+const APIFamilyIngestion APIFamily = "ingestion"
+
+// Documented hourly limit of the query and export APIs.
+const QueryRequestsPerHour = 60
+
+func apiFamily(req *http.Request) APIFamily {
+	path := req.URL.Path
+
+	switch {
+	case strings.HasPrefix(path, "/api/query"), strings.HasPrefix(path, "/api/2.0"):
+		return APIFamilyQuery
+	case strings.HasPrefix(path, "/import"), strings.HasPrefix(path, "/track"),
+		strings.HasPrefix(path, "/engage"), strings.HasPrefix(path, "/groups"):
+		return APIFamilyIngestion
+	default:
+		return APIFamilyApp
+	}
+}
+
+// RateLimiter is a token bucket spreading requests over an hourly budget.
+// The whole budget can be used in a burst, e.g. a refresh reading several
+// profiles, then requests are allowed as the bucket refills.
+type RateLimiter struct {
+	mu       sync.Mutex
+	capacity float64
+	tokens   float64
+	rate     float64 // tokens per second
+	last     time.Time
+}
+
+func NewRateLimiter(requestsPerHour int64) *RateLimiter {
+	capacity := math.Max(1, float64(requestsPerHour))
+
+	return &RateLimiter{
+		capacity: capacity,
+		tokens:   capacity,
+		rate:     float64(requestsPerHour) / 3600,
+		last:     time.Now(),
+	}
+}
+
+// Wait blocks until a request can be sent or the context is done.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	for {
+		l.mu.Lock()
+		now := time.Now()
+		l.tokens = math.Min(l.capacity, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+		l.last = now
+
+		if l.tokens >= 1 {
+			l.tokens--
+			l.mu.Unlock()
+			return nil
+		}
+
+		wait := time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+		l.mu.Unlock()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package mixpanel_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"terraform-provider-mixpanel/internal/mixpanel"
+)
+
+func TestRateLimiter(t *testing.T) {
+	limiter := mixpanel.NewRateLimiter(mixpanel.QueryRequestsPerHour)
+
+	// The whole hourly budget is available right away
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	for i := 0; i < mixpanel.QueryRequestsPerHour; i++ {
+		if err := limiter.Wait(ctx); err != nil {
+			t.Fatalf("request %d: %s", i+1, err)
+		}
+	}
+
+	// The next request waits for the bucket to refill, about a minute
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if err := limiter.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the request to be throttled, got %v", err)
+	}
+}
+
+func TestClientQueryBurst(t *testing.T) {
+	client, server := newTestClient(t)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	project := server.AddProject(mixpanel.Project{Name: "test"})
+
+	// Each call sends two query requests, the default limits let them
+	// through without waiting
+	for i := 0; i < 5; i++ {
+		if _, err := client.GetEventVolume(ctx, project.Id, mixpanel.RegionUS, mixpanel.EventVolumeDays); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package mixpanel
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+// RetryPolicy controls how failed requests are retried. Rate limited
+// requests (429) wait for as long as Mixpanel asks in the Retry-After header,
+// server errors (5xx) back off exponentially.
+type RetryPolicy struct {
+	MaxRetries   int
+	RetryWaitMin time.Duration
+	// RetryWaitMax caps the exponential backoff. It is also the budget of a
+	// single rate limited retry: when Mixpanel asks to wait longer, e.g.
+	// because the hourly limit of an API is exhausted, the request fails
+	// right away instead of blocking the whole run.
+	RetryWaitMax time.Duration
+}
+
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries:   4,
+	RetryWaitMin: 1 * time.Second,
+	RetryWaitMax: 30 * time.Second,
+}
+
+func newRetryClient(policy RetryPolicy) *retryablehttp.Client {
+	retryClient := retryablehttp.NewClient()
+	retryClient.RetryMax = policy.MaxRetries
+	retryClient.RetryWaitMin = policy.RetryWaitMin
+	retryClient.RetryWaitMax = policy.RetryWaitMax
+	retryClient.CheckRetry = policy.checkRetry
+	retryClient.Backoff = backoff
+	// Hand the last response back to doRequest so the Mixpanel error is
+	// reported instead of a generic "giving up after N attempts".
+	retryClient.ErrorHandler = retryablehttp.PassthroughErrorHandler
+	// Requests are logged by the logging transport, through the provider logs
+	retryClient.Logger = nil
+
+	return retryClient
+}
+
+func (p RetryPolicy) checkRetry(ctx context.Context, resp *http.Response, err error) (bool, error) {
+	retry, checkErr := retryablehttp.DefaultRetryPolicy(ctx, resp, err)
+	if !retry || resp == nil || resp.StatusCode != http.StatusTooManyRequests {
+		return retry, checkErr
+	}
+
+	if wait, ok := retryAfter(resp); ok && wait > p.RetryWaitMax {
+		return false, nil
+	}
+
+	return true, nil
+}
+
+func backoff(waitMin, waitMax time.Duration, attemptNum int, resp *http.Response) time.Duration {
+	if resp != nil && (resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable) {
+		if wait, ok := retryAfter(resp); ok {
+			return time.Duration(math.Min(float64(wait), float64(waitMax)))
+		}
+	}
+
+	wait := time.Duration(math.Pow(2, float64(attemptNum)) * float64(waitMin))
+	if wait <= 0 || wait > waitMax {
+		wait = waitMax
+	}
+
+	// Spread retries of concurrent requests hitting the same error
+	jitter := time.Duration(rand.Int63n(int64(wait)/4 + 1))
+	return wait - jitter
+}
+
+// retryAfter parses the Retry-After header, given either in seconds or as
+// an HTTP date.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,69 @@
+package mixpanel
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+type SessionReplaySettings struct {
+	SamplingRate  float64 `json:"sampling_rate"`
+	MaskAllText   bool    `json:"mask_all_text"`
+	MaskAllInputs bool    `json:"mask_all_inputs"`
+	MaskAllImages bool    `json:"mask_all_images"`
+	RetentionDays int64   `json:"retention_days"`
+}
+
+type SessionReplaySettingsResponse struct {
+	Status  string                `json:"status"`
+	Results SessionReplaySettings `json:"results"`
+}
+
+func (c *Client) GetSessionReplaySettings(ctx context.Context, projectId int64) (*SessionReplaySettings, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/api/app/projects/%d/session-replay/settings", c.HostURL, projectId), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var response SessionReplaySettingsResponse
+	err = json.Unmarshal(body, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.Results, nil
+}
+
+func (c *Client) UpdateSessionReplaySettings(ctx context.Context, projectId int64, settings *SessionReplaySettings) (*SessionReplaySettings, error) {
+	payload, err := json.Marshal(settings)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "PATCH", fmt.Sprintf("%s/api/app/projects/%d/session-replay/settings", c.HostURL, projectId), bytes.NewBuffer(payload))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", "application/json")
+
+	body, err := c.doRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var response SessionReplaySettingsResponse
+	err = json.Unmarshal(body, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.Results, nil
+}
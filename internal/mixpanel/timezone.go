@@ -1,6 +1,7 @@
 package mixpanel
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -16,9 +17,26 @@ type TimezoneResponse struct {
 	Results [][]interface{} `json:"results"`
 }
 
-func (c *Client) GetTimezones() ([]Timezone, error) {
+// GetTimezones returns the timezones supported by Mixpanel. The list is
+// cached by the client.
+func (c *Client) GetTimezones(ctx context.Context) ([]Timezone, error) {
+	timezones, err := c.cached(ctx, "timezones", func(ctx context.Context) (interface{}, error) {
+		return c.fetchTimezones(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	timezoneSlice, ok := timezones.([]Timezone)
+	if !ok {
+		return nil, fmt.Errorf("unexpected cached timezones: %T", timezones)
+	}
 
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/api/app/timezones", c.HostURL), nil)
+	return timezoneSlice, nil
+}
+
+func (c *Client) fetchTimezones(ctx context.Context) ([]Timezone, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/api/app/timezones", c.HostURL), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -36,6 +54,9 @@ func (c *Client) GetTimezones() ([]Timezone, error) {
 
 	timezones := make([]Timezone, 0)
 	for _, result := range response.Results {
+		if len(result) < 2 {
+			return nil, fmt.Errorf("could not parse timezone: %v", result)
+		}
 
 		id, ok := result[0].(float64) // Encoder default to float64 for JSON numbers
 		if !ok {
@@ -57,17 +78,15 @@ func (c *Client) GetTimezones() ([]Timezone, error) {
 	return timezones, nil
 }
 
-func (c *Client) GetTimezoneId(name string) (int64, error) {
-	timezones, err := c.GetTimezones()
+func (c *Client) GetTimezoneId(ctx context.Context, name string) (int64, error) {
+	timezones, err := c.GetTimezones(ctx)
 	if err != nil {
 		return 0, err
 	}
 
-	fmt.Printf("Timezones: %v", timezones)
-
 	for _, timezone := range timezones {
-		fmt.Printf("%s %s", timezone.Name, name)
 		if timezone.Name == name {
+			logDebug(ctx, "Resolved timezone", map[string]interface{}{"timezone": name, "timezone_id": timezone.Id})
 			return timezone.Id, nil
 		}
 	}
@@ -75,8 +94,8 @@ func (c *Client) GetTimezoneId(name string) (int64, error) {
 	return 0, fmt.Errorf("Timezone not found: %s", name)
 }
 
-func (c *Client) TimezoneIsSupported(name string) (bool, error) {
-	timezones, err := c.GetTimezones()
+func (c *Client) TimezoneIsSupported(ctx context.Context, name string) (bool, error) {
+	timezones, err := c.GetTimezones(ctx)
 	if err != nil {
 		return false, err
 	}
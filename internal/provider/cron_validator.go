@@ -0,0 +1,127 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+var _ validator.String = cronScheduleValidator{}
+
+// cronField describes the accepted range of one field of a cron expression.
+type cronField struct {
+	name  string
+	min   int
+	max   int
+	names map[string]int
+}
+
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: map[string]int{
+		"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+		"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+	}},
+	// 7 is accepted as an alias for Sunday, like most cron implementations.
+	{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6,
+	}},
+}
+
+// cronScheduleValidator checks that a string is a standard five field cron
+// expression (minute, hour, day of month, month, day of week).
+type cronScheduleValidator struct{}
+
+func (v cronScheduleValidator) Description(_ context.Context) string {
+	return "value must be a five field cron expression (minute hour day-of-month month day-of-week)"
+}
+
+func (v cronScheduleValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v cronScheduleValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if err := validateCronExpression(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Cron Schedule",
+			fmt.Sprintf("%q is not a valid schedule: %s.", req.ConfigValue.ValueString(), err),
+		)
+	}
+}
+
+func validateCronExpression(expression string) error {
+	fields := strings.Fields(expression)
+	if len(fields) != len(cronFields) {
+		return fmt.Errorf("expected %d fields, got %d", len(cronFields), len(fields))
+	}
+
+	for i, field := range fields {
+		for _, part := range strings.Split(field, ",") {
+			if err := validateCronPart(part, cronFields[i]); err != nil {
+				return fmt.Errorf("%s field: %w", cronFields[i].name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateCronPart(part string, field cronField) error {
+	rangePart, step, hasStep := strings.Cut(part, "/")
+	if hasStep {
+		n, err := strconv.Atoi(step)
+		if err != nil || n <= 0 {
+			return fmt.Errorf("invalid step %q", step)
+		}
+	}
+
+	if rangePart == "*" {
+		return nil
+	}
+
+	low, high, isRange := strings.Cut(rangePart, "-")
+	lowValue, err := parseCronValue(low, field)
+	if err != nil {
+		return err
+	}
+
+	if !isRange {
+		return nil
+	}
+
+	highValue, err := parseCronValue(high, field)
+	if err != nil {
+		return err
+	}
+	if lowValue > highValue {
+		return fmt.Errorf("invalid range %q", rangePart)
+	}
+
+	return nil
+}
+
+func parseCronValue(value string, field cronField) (int, error) {
+	if n, ok := field.names[strings.ToUpper(value)]; ok {
+		return n, nil
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", value)
+	}
+	if n < field.min || n > field.max {
+		return 0, fmt.Errorf("value %d out of range %d-%d", n, field.min, field.max)
+	}
+
+	return n, nil
+}
@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"testing"
+)
+
+func TestValidateCronExpression(t *testing.T) {
+	tests := []struct {
+		expression string
+		valid      bool
+	}{
+		{"* * * * *", true},
+		{"0 9 * * MON", true},
+		{"30 8 1,15 * *", true},
+		{"0 9-17 * * 1-5", true},
+		{"*/15 * * * *", true},
+		{"0 0-12/2 * * *", true},
+		{"0 9 * jan,Jul MON-FRI", true},
+		{"0 9 * * SUN-SAT", true},
+		{"0 9 * * 7", true},
+		{"0 9 * * 5-7", true},
+		{"  0   9 * *   *  ", true},
+
+		// Wrong field counts
+		{"", false},
+		{"* * * *", false},
+		{"* * * * * *", false},
+
+		// Out of range values
+		{"60 * * * *", false},
+		{"* 24 * * *", false},
+		{"* * 0 * *", false},
+		{"* * 32 * *", false},
+		{"* * * 13 *", false},
+		{"* * * * 8", false},
+
+		// Invalid ranges, steps and names
+		{"* 17-9 * * *", false},
+		{"* 9- * * *", false},
+		{"*/0 * * * *", false},
+		{"*/-1 * * * *", false},
+		{"*/x * * * *", false},
+		{"* * * * MONDAY", false},
+		{"* * * MON * ", false},
+		{"* * * * JAN", false},
+
+		// Empty list items
+		{"1,,2 * * * *", false},
+		{", * * * *", false},
+		{"1, * * * *", false},
+	}
+
+	for _, test := range tests {
+		err := validateCronExpression(test.expression)
+		if test.valid && err != nil {
+			t.Errorf("%q: unexpected error: %s", test.expression, err)
+		}
+		if !test.valid && err == nil {
+			t.Errorf("%q: expected an error", test.expression)
+		}
+	}
+}
@@ -0,0 +1,407 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"terraform-provider-mixpanel/internal/mixpanel"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &dashboardSubscriptionResource{}
+	_ resource.ResourceWithConfigure      = &dashboardSubscriptionResource{}
+	_ resource.ResourceWithImportState    = &dashboardSubscriptionResource{}
+	_ resource.ResourceWithValidateConfig = &dashboardSubscriptionResource{}
+)
+
+// NewDashboardSubscriptionResource is a helper function to simplify the provider implementation.
+func NewDashboardSubscriptionResource() resource.Resource {
+	return &dashboardSubscriptionResource{}
+}
+
+// dashboardSubscriptionResource is the resource implementation.
+type dashboardSubscriptionResource struct {
+	client *mixpanel.Client
+}
+
+type DashboardSubscriptionModel struct {
+	Id           types.Int64    `tfsdk:"id"`
+	ProjectId    types.Int64    `tfsdk:"project_id"`
+	DashboardId  types.Int64    `tfsdk:"dashboard_id"`
+	Channel      types.String   `tfsdk:"channel"`
+	Recipients   types.Set      `tfsdk:"recipients"`
+	SlackChannel types.String   `tfsdk:"slack_channel"`
+	Schedule     types.String   `tfsdk:"schedule"`
+	Timezone     types.String   `tfsdk:"timezone"`
+	Timeouts     timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *dashboardSubscriptionResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*mixpanel.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *mixpanel.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// Metadata returns the resource type name.
+func (r *dashboardSubscriptionResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dashboard_subscription"
+}
+
+// Schema defines the schema for the resource.
+func (r *dashboardSubscriptionResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Scheduled delivery of a Mixpanel board by email or Slack.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_id": schema.Int64Attribute{
+				MarkdownDescription: "ID of the project the board belongs to.",
+				Required:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"dashboard_id": schema.Int64Attribute{
+				MarkdownDescription: "ID of the board to deliver.",
+				Required:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"channel": schema.StringAttribute{
+				MarkdownDescription: "Delivery channel, either `email` or `slack`.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(mixpanel.DashboardSubscriptionChannelEmail, mixpanel.DashboardSubscriptionChannelSlack),
+				},
+			},
+			"recipients": schema.SetAttribute{
+				MarkdownDescription: "Email addresses receiving the board. Required when `channel` is `email`.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"slack_channel": schema.StringAttribute{
+				MarkdownDescription: "Slack channel receiving the board. Required when `channel` is `slack`.",
+				Optional:            true,
+			},
+			"schedule": schema.StringAttribute{
+				MarkdownDescription: "Delivery schedule as a five field cron expression, e.g. `0 9 * * MON`.",
+				Required:            true,
+				Validators: []validator.String{
+					cronScheduleValidator{},
+				},
+			},
+			"timezone": schema.StringAttribute{
+				MarkdownDescription: "Timezone the schedule is evaluated in. Defaults to the project timezone.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// ValidateConfig checks that the destination matching the channel is set.
+func (r *dashboardSubscriptionResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config DashboardSubscriptionModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	switch config.Channel.ValueString() {
+	case mixpanel.DashboardSubscriptionChannelEmail:
+		if config.Recipients.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("recipients"),
+				"Missing Recipients",
+				"recipients must be set when channel is \"email\".",
+			)
+		}
+	case mixpanel.DashboardSubscriptionChannelSlack:
+		if config.SlackChannel.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("slack_channel"),
+				"Missing Slack Channel",
+				"slack_channel must be set when channel is \"slack\".",
+			)
+		}
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *dashboardSubscriptionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state DashboardSubscriptionModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	subscription, err := r.client.GetDashboardSubscription(ctx, state.ProjectId.ValueInt64(), state.DashboardId.ValueInt64(), state.Id.ValueInt64())
+	if err != nil {
+		if mixpanel.IsNotFound(err) {
+			// The dashboard subscription was deleted outside of Terraform, plan to create it again
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error Reading Mixpanel Dashboard Subscription",
+			"Could not read Mixpanel dashboard subscription ID "+strconv.FormatInt(state.Id.ValueInt64(), 10)+": "+err.Error(),
+		)
+		return
+	}
+
+	state, diags = DashboardSubscriptionToModel(state.ProjectId.ValueInt64(), subscription, state.Timeouts)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *dashboardSubscriptionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan DashboardSubscriptionModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	data, diags := DashboardSubscriptionModelToSubscription(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	subscription, err := r.client.CreateDashboardSubscription(ctx, plan.ProjectId.ValueInt64(), data)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to create Mixpanel Dashboard Subscription",
+			err.Error(),
+		)
+		return
+	}
+
+	state, diags := DashboardSubscriptionToModel(plan.ProjectId.ValueInt64(), subscription, plan.Timeouts)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *dashboardSubscriptionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan DashboardSubscriptionModel
+	var state DashboardSubscriptionModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data, diags := DashboardSubscriptionModelToSubscription(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Id = state.Id.ValueInt64()
+
+	subscription, err := r.client.UpdateDashboardSubscription(ctx, state.ProjectId.ValueInt64(), data)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to update Mixpanel Dashboard Subscription",
+			err.Error(),
+		)
+		return
+	}
+
+	state, diags = DashboardSubscriptionToModel(state.ProjectId.ValueInt64(), subscription, plan.Timeouts)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *dashboardSubscriptionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state DashboardSubscriptionModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	err := r.client.DeleteDashboardSubscription(ctx, state.ProjectId.ValueInt64(), state.DashboardId.ValueInt64(), state.Id.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to delete Mixpanel Dashboard Subscription",
+			err.Error(),
+		)
+		return
+	}
+}
+
+func (r *dashboardSubscriptionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ids, err := parseImportId(req.ID, "project_id", "dashboard_id", "id")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid ID",
+			err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), ids[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("dashboard_id"), ids[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), ids[2])...)
+}
+
+func DashboardSubscriptionModelToSubscription(ctx context.Context, model *DashboardSubscriptionModel) (*mixpanel.DashboardSubscription, diag.Diagnostics) {
+	subscription := mixpanel.DashboardSubscription{
+		DashboardId:  model.DashboardId.ValueInt64(),
+		Channel:      model.Channel.ValueString(),
+		SlackChannel: model.SlackChannel.ValueString(),
+		Schedule:     model.Schedule.ValueString(),
+		Timezone:     model.Timezone.ValueString(),
+	}
+
+	var diags diag.Diagnostics
+	if !model.Recipients.IsNull() && !model.Recipients.IsUnknown() {
+		diags = model.Recipients.ElementsAs(ctx, &subscription.Recipients, false)
+	}
+
+	return &subscription, diags
+}
+
+func DashboardSubscriptionToModel(projectId int64, subscription *mixpanel.DashboardSubscription, modelTimeouts timeouts.Value) (DashboardSubscriptionModel, diag.Diagnostics) {
+	model := DashboardSubscriptionModel{
+		Id:           types.Int64Value(subscription.Id),
+		ProjectId:    types.Int64Value(projectId),
+		DashboardId:  types.Int64Value(subscription.DashboardId),
+		Channel:      types.StringValue(subscription.Channel),
+		SlackChannel: stringValueOrNull(subscription.SlackChannel),
+		Schedule:     types.StringValue(subscription.Schedule),
+		Timezone:     stringValueOrNull(subscription.Timezone),
+		Recipients:   types.SetNull(types.StringType),
+		Timeouts:     modelTimeouts,
+	}
+
+	var diags diag.Diagnostics
+	if len(subscription.Recipients) > 0 {
+		recipients := make([]attr.Value, len(subscription.Recipients))
+		for i, recipient := range subscription.Recipients {
+			recipients[i] = types.StringValue(recipient)
+		}
+		model.Recipients, diags = types.SetValue(types.StringType, recipients)
+	}
+
+	return model, diags
+}
+
+// stringValueOrNull maps the empty strings returned by the API for unset
+// fields to null, so optional attributes left out of the configuration
+// don't show a diff.
+func stringValueOrNull(value string) types.String {
+	if value == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(value)
+}
@@ -0,0 +1,122 @@
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"terraform-provider-mixpanel/internal/mixpanel"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+// testAccResourceId stores the ID of a resource in state into id.
+func testAccResourceId(name string, id *int64) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("%s not found in state", name)
+		}
+
+		var err error
+		*id, err = strconv.ParseInt(rs.Primary.ID, 10, 64)
+		return err
+	}
+}
+
+func TestAccDashboardSubscriptionResource(t *testing.T) {
+	server := testAccServer(t)
+	project := server.AddProject(mixpanel.Project{Name: "test", Timezone: "Europe/Paris"})
+	var id int64
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The timezone defaults to the one of the project
+			{
+				Config: testAccProviderConfig(server) + fmt.Sprintf(`
+resource "mixpanel_dashboard_subscription" "test" {
+  project_id   = %d
+  dashboard_id = 42
+  channel      = "email"
+  recipients   = ["product@example.com"]
+  schedule     = "0 9 * * MON"
+}
+`, project.Id),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccResourceId("mixpanel_dashboard_subscription.test", &id),
+					resource.TestCheckResourceAttr("mixpanel_dashboard_subscription.test", "timezone", "Europe/Paris"),
+					resource.TestCheckResourceAttr("mixpanel_dashboard_subscription.test", "recipients.#", "1"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName: "mixpanel_dashboard_subscription.test",
+				ImportState:  true,
+				ImportStateIdFunc: func(*terraform.State) (string, error) {
+					return fmt.Sprintf("%d/42/%d", project.Id, id), nil
+				},
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"timeouts"},
+			},
+			// Update testing
+			{
+				Config: testAccProviderConfig(server) + fmt.Sprintf(`
+resource "mixpanel_dashboard_subscription" "test" {
+  project_id    = %d
+  dashboard_id  = 42
+  channel       = "slack"
+  slack_channel = "#product-metrics"
+  schedule      = "30 8 * * *"
+  timezone      = "UTC"
+}
+`, project.Id),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mixpanel_dashboard_subscription.test", "timezone", "UTC"),
+					resource.TestCheckNoResourceAttr("mixpanel_dashboard_subscription.test", "recipients"),
+					func(*terraform.State) error {
+						subscription, ok := server.DashboardSubscription(project.Id, id)
+						if !ok || subscription.Channel != "slack" || subscription.SlackChannel != "#product-metrics" || len(subscription.Recipients) > 0 {
+							return fmt.Errorf("subscription not updated in Mixpanel: %+v", subscription)
+						}
+						return nil
+					},
+				),
+			},
+			// Switching back to email clears the Slack channel
+			{
+				Config: testAccProviderConfig(server) + fmt.Sprintf(`
+resource "mixpanel_dashboard_subscription" "test" {
+  project_id   = %d
+  dashboard_id = 42
+  channel      = "email"
+  recipients   = ["product@example.com", "growth@example.com"]
+  schedule     = "30 8 * * *"
+  timezone     = "UTC"
+}
+`, project.Id),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mixpanel_dashboard_subscription.test", "recipients.#", "2"),
+					resource.TestCheckNoResourceAttr("mixpanel_dashboard_subscription.test", "slack_channel"),
+					func(*terraform.State) error {
+						subscription, ok := server.DashboardSubscription(project.Id, id)
+						if !ok || subscription.Channel != "email" || subscription.SlackChannel != "" || len(subscription.Recipients) != 2 {
+							return fmt.Errorf("subscription not updated in Mixpanel: %+v", subscription)
+						}
+						return nil
+					},
+				),
+			},
+			// A subscription deleted outside of Terraform is created again
+			{
+				PreConfig: func() {
+					server.DeleteDashboardSubscription(project.Id, id)
+				},
+				RefreshState:       true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
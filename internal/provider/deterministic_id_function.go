@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"terraform-provider-mixpanel/internal/mixpanel"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ function.Function = &deterministicIdFunction{}
+	_ function.Function = &isValidInsertIdFunction{}
+)
+
+// Well known namespaces, named as in the uuidv5 Terraform function.
+var uuidNamespaces = map[string]uuid.UUID{
+	"dns":  uuid.NameSpaceDNS,
+	"url":  uuid.NameSpaceURL,
+	"oid":  uuid.NameSpaceOID,
+	"x500": uuid.NameSpaceX500,
+}
+
+// NewDeterministicIdFunction is a helper function to simplify the provider implementation.
+func NewDeterministicIdFunction() function.Function {
+	return &deterministicIdFunction{}
+}
+
+// deterministicIdFunction derives stable ids from a namespace and a key.
+type deterministicIdFunction struct{}
+
+func (f *deterministicIdFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "deterministic_id"
+}
+
+func (f *deterministicIdFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Derive a stable ID from a namespace and a key",
+		MarkdownDescription: "Derives a UUIDv5 from a namespace and a key. The same inputs always give the same ID, " +
+			"which makes it suitable as the `$insert_id` of seeded events, so that importing them again is deduplicated by Mixpanel, " +
+			"or as the `distinct_id` of seeded profiles.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name: "namespace",
+				MarkdownDescription: "A UUID, one of the well known namespaces `dns`, `url`, `oid` or `x500`, " +
+					"or any other string, first turned into a UUID with UUIDv5 in the `url` namespace.",
+			},
+			function.StringParameter{
+				Name:                "key",
+				MarkdownDescription: "Key identifying the event or profile within the namespace.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *deterministicIdFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var namespace, key string
+
+	resp.Error = req.Arguments.Get(ctx, &namespace, &key)
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, deterministicId(namespace, key))
+}
+
+func deterministicId(namespace, key string) string {
+	namespaceId, ok := uuidNamespaces[strings.ToLower(namespace)]
+	if !ok {
+		var err error
+		namespaceId, err = uuid.Parse(namespace)
+		if err != nil {
+			namespaceId = uuid.NewSHA1(uuid.NameSpaceURL, []byte(namespace))
+		}
+	}
+
+	return uuid.NewSHA1(namespaceId, []byte(key)).String()
+}
+
+// NewIsValidInsertIdFunction is a helper function to simplify the provider implementation.
+func NewIsValidInsertIdFunction() function.Function {
+	return &isValidInsertIdFunction{}
+}
+
+// isValidInsertIdFunction checks ids against the $insert_id constraints.
+type isValidInsertIdFunction struct{}
+
+func (f *isValidInsertIdFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "is_valid_insert_id"
+}
+
+func (f *isValidInsertIdFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Check an ID against the Mixpanel $insert_id constraints",
+		MarkdownDescription: "Checks whether an ID can be used as the `$insert_id` of an event: Mixpanel only deduplicates events " +
+			"whose `$insert_id` is at most 36 bytes long and only contains alphanumeric characters and dashes.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "id",
+				MarkdownDescription: "ID to check.",
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (f *isValidInsertIdFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var id string
+
+	resp.Error = req.Arguments.Get(ctx, &id)
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, mixpanel.ValidateInsertId(id) == nil)
+}
@@ -0,0 +1,37 @@
+package provider
+
+import (
+	"testing"
+
+	"terraform-provider-mixpanel/internal/mixpanel"
+)
+
+func TestDeterministicId(t *testing.T) {
+	// Same as uuidv5("dns", "www.example.com") in Terraform
+	if id := deterministicId("dns", "www.example.com"); id != "2ed6657d-e927-568b-95e1-2665a8aea6a2" {
+		t.Errorf("unexpected id for a well known namespace: %s", id)
+	}
+
+	if id := deterministicId("6ba7b810-9dad-11d1-80b4-00c04fd430c8", "www.example.com"); id != "2ed6657d-e927-568b-95e1-2665a8aea6a2" {
+		t.Errorf("unexpected id for a UUID namespace: %s", id)
+	}
+
+	id := deterministicId("seed-events", "signup-1")
+	if id != deterministicId("seed-events", "signup-1") {
+		t.Error("expected the same id for the same inputs")
+	}
+	if id == deterministicId("seed-profiles", "signup-1") {
+		t.Error("expected different ids in different namespaces")
+	}
+	if err := mixpanel.ValidateInsertId(id); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestValidateInsertId(t *testing.T) {
+	for _, id := range []string{"", "with space", "under_score", "0123456789012345678901234567890123456"} {
+		if err := mixpanel.ValidateInsertId(id); err == nil {
+			t.Errorf("expected %q to be rejected", id)
+		}
+	}
+}
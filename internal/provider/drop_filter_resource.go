@@ -0,0 +1,451 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"terraform-provider-mixpanel/internal/mixpanel"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &dropFilterResource{}
+	_ resource.ResourceWithConfigure      = &dropFilterResource{}
+	_ resource.ResourceWithImportState    = &dropFilterResource{}
+	_ resource.ResourceWithValidateConfig = &dropFilterResource{}
+)
+
+// NewDropFilterResource is a helper function to simplify the provider implementation.
+func NewDropFilterResource() resource.Resource {
+	return &dropFilterResource{}
+}
+
+// dropFilterResource is the resource implementation.
+type dropFilterResource struct {
+	client *mixpanel.Client
+}
+
+type DropFilterModel struct {
+	Id               types.Int64                `tfsdk:"id"`
+	ProjectId        types.Int64                `tfsdk:"project_id"`
+	EventNamePattern types.String               `tfsdk:"event_name_pattern"`
+	Conditions       []DropFilterConditionModel `tfsdk:"conditions"`
+	Properties       types.Set                  `tfsdk:"properties"`
+	Description      types.String               `tfsdk:"description"`
+	Enabled          types.Bool                 `tfsdk:"enabled"`
+	Timeouts         timeouts.Value             `tfsdk:"timeouts"`
+}
+
+type DropFilterConditionModel struct {
+	Property types.String `tfsdk:"property"`
+	Operator types.String `tfsdk:"operator"`
+	Value    types.String `tfsdk:"value"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *dropFilterResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*mixpanel.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *mixpanel.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// Metadata returns the resource type name.
+func (r *dropFilterResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_drop_filter"
+}
+
+// Schema defines the schema for the resource.
+func (r *dropFilterResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Ingestion filter blocking matching events, or some of their properties, in a Mixpanel project.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_id": schema.Int64Attribute{
+				MarkdownDescription: "ID of the project the filter applies to.",
+				Required:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"event_name_pattern": schema.StringAttribute{
+				MarkdownDescription: "Name of the events to filter, `*` matches any sequence of characters.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"conditions": schema.ListNestedAttribute{
+				MarkdownDescription: "Property conditions an event must all match to be filtered.",
+				Optional:            true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"property": schema.StringAttribute{
+							MarkdownDescription: "Name of the event property.",
+							Required:            true,
+						},
+						"operator": schema.StringAttribute{
+							MarkdownDescription: "One of `equals`, `not_equals`, `contains`, `not_contains`, `is_set` or `is_not_set`.",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(
+									mixpanel.DropFilterOperatorEquals,
+									mixpanel.DropFilterOperatorNotEquals,
+									mixpanel.DropFilterOperatorContains,
+									mixpanel.DropFilterOperatorNotContains,
+									mixpanel.DropFilterOperatorIsSet,
+									mixpanel.DropFilterOperatorIsNotSet,
+								),
+							},
+						},
+						"value": schema.StringAttribute{
+							MarkdownDescription: "Value compared to the property. Not allowed with `is_set` and `is_not_set`.",
+							Optional:            true,
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+					},
+				},
+			},
+			"properties": schema.SetAttribute{
+				MarkdownDescription: "Properties stripped from matching events. When omitted, matching events are dropped entirely.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Why the filter exists, shown in the Mixpanel UI.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether the filter is applied. Default is `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// ValidateConfig checks that condition values match their operator.
+func (r *dropFilterResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var conditionList types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("conditions"), &conditionList)...)
+	if resp.Diagnostics.HasError() || conditionList.IsNull() || conditionList.IsUnknown() {
+		return
+	}
+
+	var conditions []DropFilterConditionModel
+	resp.Diagnostics.Append(conditionList.ElementsAs(ctx, &conditions, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for i, condition := range conditions {
+		if condition.Operator.IsUnknown() || condition.Value.IsUnknown() {
+			continue
+		}
+
+		valuePath := path.Root("conditions").AtListIndex(i).AtName("value")
+		switch condition.Operator.ValueString() {
+		case mixpanel.DropFilterOperatorIsSet, mixpanel.DropFilterOperatorIsNotSet:
+			if !condition.Value.IsNull() {
+				resp.Diagnostics.AddAttributeError(
+					valuePath,
+					"Unexpected Condition Value",
+					fmt.Sprintf("value cannot be set with the %q operator.", condition.Operator.ValueString()),
+				)
+			}
+		default:
+			if condition.Value.IsNull() {
+				resp.Diagnostics.AddAttributeError(
+					valuePath,
+					"Missing Condition Value",
+					fmt.Sprintf("value is required with the %q operator.", condition.Operator.ValueString()),
+				)
+			}
+		}
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *dropFilterResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state DropFilterModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	filter, err := r.client.GetDropFilter(ctx, state.ProjectId.ValueInt64(), state.Id.ValueInt64())
+	if err != nil {
+		if mixpanel.IsNotFound(err) {
+			// The drop filter was deleted outside of Terraform, plan to create it again
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error Reading Mixpanel Drop Filter",
+			"Could not read Mixpanel drop filter ID "+strconv.FormatInt(state.Id.ValueInt64(), 10)+": "+err.Error(),
+		)
+		return
+	}
+
+	state, diags = DropFilterToModel(ctx, state.ProjectId.ValueInt64(), filter, state.Timeouts)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *dropFilterResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan DropFilterModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	data, diags := DropFilterModelToDropFilter(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filter, err := r.client.CreateDropFilter(ctx, plan.ProjectId.ValueInt64(), data)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to create Mixpanel Drop Filter",
+			err.Error(),
+		)
+		return
+	}
+
+	state, diags := DropFilterToModel(ctx, plan.ProjectId.ValueInt64(), filter, plan.Timeouts)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *dropFilterResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan DropFilterModel
+	var state DropFilterModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data, diags := DropFilterModelToDropFilter(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Id = state.Id.ValueInt64()
+
+	filter, err := r.client.UpdateDropFilter(ctx, state.ProjectId.ValueInt64(), data)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to update Mixpanel Drop Filter",
+			err.Error(),
+		)
+		return
+	}
+
+	state, diags = DropFilterToModel(ctx, state.ProjectId.ValueInt64(), filter, plan.Timeouts)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *dropFilterResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state DropFilterModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	err := r.client.DeleteDropFilter(ctx, state.ProjectId.ValueInt64(), state.Id.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to delete Mixpanel Drop Filter",
+			err.Error(),
+		)
+		return
+	}
+}
+
+func (r *dropFilterResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ids, err := parseImportId(req.ID, "project_id", "id")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid ID",
+			err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), ids[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), ids[1])...)
+}
+
+func DropFilterModelToDropFilter(ctx context.Context, model *DropFilterModel) (*mixpanel.DropFilter, diag.Diagnostics) {
+	filter := mixpanel.DropFilter{
+		EventNamePattern: model.EventNamePattern.ValueString(),
+		Conditions:       []mixpanel.DropFilterCondition{},
+		Properties:       []string{},
+		Description:      model.Description.ValueString(),
+		Enabled:          model.Enabled.ValueBool(),
+	}
+
+	for _, condition := range model.Conditions {
+		filter.Conditions = append(filter.Conditions, mixpanel.DropFilterCondition{
+			Property: condition.Property.ValueString(),
+			Operator: condition.Operator.ValueString(),
+			Value:    condition.Value.ValueString(),
+		})
+	}
+
+	var diags diag.Diagnostics
+	if !model.Properties.IsNull() && !model.Properties.IsUnknown() {
+		diags = model.Properties.ElementsAs(ctx, &filter.Properties, false)
+	}
+
+	return &filter, diags
+}
+
+func DropFilterToModel(ctx context.Context, projectId int64, filter *mixpanel.DropFilter, modelTimeouts timeouts.Value) (DropFilterModel, diag.Diagnostics) {
+	model := DropFilterModel{
+		Id:               types.Int64Value(filter.Id),
+		ProjectId:        types.Int64Value(projectId),
+		EventNamePattern: types.StringValue(filter.EventNamePattern),
+		Properties:       types.SetNull(types.StringType),
+		Description:      stringValueOrNull(filter.Description),
+		Enabled:          types.BoolValue(filter.Enabled),
+		Timeouts:         modelTimeouts,
+	}
+
+	for _, condition := range filter.Conditions {
+		model.Conditions = append(model.Conditions, DropFilterConditionModel{
+			Property: types.StringValue(condition.Property),
+			Operator: types.StringValue(condition.Operator),
+			Value:    stringValueOrNull(condition.Value),
+		})
+	}
+
+	var diags diag.Diagnostics
+	if len(filter.Properties) > 0 {
+		model.Properties, diags = types.SetValueFrom(ctx, types.StringType, filter.Properties)
+	}
+
+	return model, diags
+}
@@ -0,0 +1,136 @@
+package provider
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"testing"
+
+	"terraform-provider-mixpanel/internal/mixpanel"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccDropFilterResource(t *testing.T) {
+	server := testAccServer(t)
+	project := server.AddProject(mixpanel.Project{Name: "test"})
+	var id int64
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccProviderConfig(server) + fmt.Sprintf(`
+resource "mixpanel_drop_filter" "test" {
+  project_id         = %d
+  event_name_pattern = "debug_*"
+  description        = "Debug events"
+
+  conditions = [
+    {
+      property = "env"
+      operator = "equals"
+      value    = "staging"
+    },
+    {
+      property = "internal"
+      operator = "is_set"
+    },
+  ]
+}
+`, project.Id),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccResourceId("mixpanel_drop_filter.test", &id),
+					resource.TestCheckResourceAttr("mixpanel_drop_filter.test", "enabled", "true"),
+					resource.TestCheckResourceAttr("mixpanel_drop_filter.test", "conditions.#", "2"),
+					resource.TestCheckNoResourceAttr("mixpanel_drop_filter.test", "conditions.1.value"),
+					resource.TestCheckNoResourceAttr("mixpanel_drop_filter.test", "properties"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName: "mixpanel_drop_filter.test",
+				ImportState:  true,
+				ImportStateIdFunc: func(*terraform.State) (string, error) {
+					return fmt.Sprintf("%d/%d", project.Id, id), nil
+				},
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"timeouts"},
+			},
+			// Update testing, conditions and description are removed
+			{
+				Config: testAccProviderConfig(server) + fmt.Sprintf(`
+resource "mixpanel_drop_filter" "test" {
+  project_id         = %d
+  event_name_pattern = "debug_*"
+  properties         = ["$ip", "email"]
+  enabled            = false
+}
+`, project.Id),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mixpanel_drop_filter.test", "properties.#", "2"),
+					resource.TestCheckNoResourceAttr("mixpanel_drop_filter.test", "conditions"),
+					resource.TestCheckNoResourceAttr("mixpanel_drop_filter.test", "description"),
+					func(*terraform.State) error {
+						filter, _ := server.DropFilter(project.Id, id)
+						sort.Strings(filter.Properties)
+						expected := mixpanel.DropFilter{
+							Id:               id,
+							EventNamePattern: "debug_*",
+							Conditions:       []mixpanel.DropFilterCondition{},
+							Properties:       []string{"$ip", "email"},
+						}
+						if !reflect.DeepEqual(filter, expected) {
+							return fmt.Errorf("expected drop filter %+v, got %+v", expected, filter)
+						}
+						return nil
+					},
+				),
+			},
+			// A drop filter deleted outside of Terraform is created again
+			{
+				PreConfig: func() {
+					server.DeleteDropFilter(project.Id, id)
+				},
+				RefreshState:       true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccDropFilterResource_emptyValues(t *testing.T) {
+	server := testAccServer(t)
+
+	// Empty values would be read back as null, they are rejected instead
+	for attribute, config := range map[string]string{
+		"properties":  `properties = []`,
+		"conditions":  `conditions = []`,
+		"description": `description = ""`,
+		"value":       `conditions = [{ property = "env", operator = "equals", value = "" }]`,
+	} {
+		t.Run(attribute, func(t *testing.T) {
+			resource.Test(t, resource.TestCase{
+				PreCheck:                 func() { testAccPreCheck(t) },
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: testAccProviderConfig(server) + fmt.Sprintf(`
+resource "mixpanel_drop_filter" "test" {
+  project_id         = 1
+  event_name_pattern = "debug_*"
+  %s
+}
+`, config),
+						PlanOnly:    true,
+						ExpectError: regexp.MustCompile(`Invalid Attribute Value`),
+					},
+				},
+			})
+		})
+	}
+}
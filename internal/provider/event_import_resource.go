@@ -0,0 +1,469 @@
+package provider
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"terraform-provider-mixpanel/internal/mixpanel"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Longest line accepted in an NDJSON file, Mixpanel rejects events over 1 MB.
+const maxEventLineSize = 1 << 20
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                     = &eventImportResource{}
+	_ resource.ResourceWithConfigure        = &eventImportResource{}
+	_ resource.ResourceWithConfigValidators = &eventImportResource{}
+	_ resource.ResourceWithModifyPlan       = &eventImportResource{}
+)
+
+// NewEventImportResource is a helper function to simplify the provider implementation.
+func NewEventImportResource() resource.Resource {
+	return &eventImportResource{}
+}
+
+// eventImportResource is the resource implementation.
+type eventImportResource struct {
+	client *mixpanel.Client
+}
+
+type EventImportModel struct {
+	Id            types.String   `tfsdk:"id"`
+	ProjectId     types.Int64    `tfsdk:"project_id"`
+	Events        []EventModel   `tfsdk:"events"`
+	File          types.String   `tfsdk:"file"`
+	FileSha256    types.String   `tfsdk:"file_sha256"`
+	Strict        types.Bool     `tfsdk:"strict"`
+	ImportedCount types.Int64    `tfsdk:"imported_count"`
+	Timeouts      timeouts.Value `tfsdk:"timeouts"`
+}
+
+type EventModel struct {
+	Event      types.String `tfsdk:"event"`
+	Properties types.String `tfsdk:"properties"`
+}
+
+// importedEvent is an event to import, with where it was defined for the
+// diagnostics.
+type importedEvent struct {
+	event mixpanel.Event
+	// index in the events attribute, or line in the file
+	index int
+	line  int
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *eventImportResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*mixpanel.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *mixpanel.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// Metadata returns the resource type name.
+func (r *eventImportResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_event_import"
+}
+
+// Schema defines the schema for the resource.
+func (r *eventImportResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Imports a batch of events into a project through the `/import` API, authenticated with the project secret, " +
+			"e.g. to seed a demo or staging project. The events are imported on create, and again whenever they change. " +
+			"Events without `$insert_id` get one derived from the project, their name and their properties, " +
+			"so that Mixpanel deduplicates events imported again and identical events are only imported once. " +
+			"Destroying the resource leaves the events in the project.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Digest of the `$insert_id` of the imported events.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_id": schema.Int64Attribute{
+				MarkdownDescription: "ID of the project the events are imported into.",
+				Required:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"events": schema.ListNestedAttribute{
+				MarkdownDescription: "Events to import. Exactly one of `events` or `file` must be set.",
+				Optional:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"event": schema.StringAttribute{
+							MarkdownDescription: "Name of the event.",
+							Required:            true,
+						},
+						"properties": schema.StringAttribute{
+							MarkdownDescription: "Properties of the event as a JSON object, e.g. with `jsonencode`. " +
+								"`time` and `distinct_id` are required by the `/import` API.",
+							Required: true,
+						},
+					},
+				},
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"file": schema.StringAttribute{
+				MarkdownDescription: "Path to an NDJSON file holding the events to import, one `{\"event\": ..., \"properties\": {...}}` object per line. " +
+					"Exactly one of `events` or `file` must be set.",
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"file_sha256": schema.StringAttribute{
+				MarkdownDescription: "SHA-256 digest of `file`, the events are imported again when it changes.",
+				Computed:            true,
+			},
+			"strict": schema.BoolAttribute{
+				MarkdownDescription: "Whether Mixpanel validates the events and reports the invalid ones, instead of silently dropping them. " +
+					"Default is `true`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+			},
+			"imported_count": schema.Int64Attribute{
+				MarkdownDescription: "Number of events Mixpanel reported as imported, deduplicated events included.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+			}),
+		},
+	}
+}
+
+// ConfigValidators returns the validators of the resource configuration.
+func (r *eventImportResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("events"),
+			path.MatchRoot("file"),
+		),
+	}
+}
+
+// ModifyPlan tracks the content of file, so that the events are imported
+// again when it changes.
+func (r *eventImportResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var file types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("file"), &file)...)
+	if resp.Diagnostics.HasError() || file.IsUnknown() {
+		return
+	}
+
+	digest := types.StringNull()
+	if !file.IsNull() {
+		// The file may only be written during the apply, its digest is then
+		// known once the events are imported
+		digest = types.StringUnknown()
+		if content, err := os.ReadFile(file.ValueString()); err == nil {
+			sum := sha256.Sum256(content)
+			digest = types.StringValue(hex.EncodeToString(sum[:]))
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("file_sha256"), digest)...)
+
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var previous types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("file_sha256"), &previous)...)
+	if !previous.Equal(digest) {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("file_sha256"))
+	}
+}
+
+// Read removes the resource when the project no longer exists. Events cannot
+// be read back, the state is kept as is otherwise.
+func (r *eventImportResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state EventImportModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	_, err := r.client.GetProject(ctx, state.ProjectId.ValueInt64())
+	if err != nil {
+		if mixpanel.IsNotFound(err) {
+			// The project was deleted along with its events
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error Reading Mixpanel Project",
+			"Could not read Mixpanel project ID "+strconv.FormatInt(state.ProjectId.ValueInt64(), 10)+": "+err.Error(),
+		)
+	}
+}
+
+// Create imports the events and sets the initial Terraform state.
+func (r *eventImportResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan EventImportModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	var events []importedEvent
+	if plan.File.IsNull() {
+		events = loadConfigEvents(plan.Events, &resp.Diagnostics)
+	} else {
+		var digest string
+		events, digest = loadFileEvents(plan.File.ValueString(), &resp.Diagnostics)
+		plan.FileSha256 = types.StringValue(digest)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	insertIds := sha256.New()
+	for i := range events {
+		addInsertId(plan.ProjectId.ValueInt64(), &events[i], &resp.Diagnostics)
+		insertId, _ := events[i].event.Properties["$insert_id"].(string)
+		insertIds.Write([]byte(insertId + "\n"))
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	project, err := r.client.GetProject(ctx, plan.ProjectId.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Mixpanel Project",
+			err.Error(),
+		)
+		return
+	}
+
+	batch := make([]mixpanel.Event, len(events))
+	for i, event := range events {
+		batch[i] = event.event
+	}
+
+	ingestionClient := r.client.WithAuthenticator(&mixpanel.ProjectSecretAuthenticator{Secret: project.Secret})
+	imported, err := ingestionClient.ImportEvents(ctx, project.Id, project.Domain, batch, plan.Strict.ValueBool())
+	if err != nil {
+		var importErr *mixpanel.ImportError
+		if !errors.As(err, &importErr) {
+			resp.Diagnostics.AddError(
+				"Unable to import Mixpanel Events",
+				fmt.Sprintf("%d events were imported before the error, they are deduplicated when imported again: %s", imported, err.Error()),
+			)
+			return
+		}
+
+		for _, record := range importErr.FailedRecords {
+			if record.Index < 0 || record.Index >= len(events) {
+				continue
+			}
+			addEventError(events[record.Index], &resp.Diagnostics, "Invalid Mixpanel Event",
+				fmt.Sprintf("Mixpanel rejected %s: %s", record.Field, record.Message))
+		}
+		resp.Diagnostics.AddError(
+			"Unable to import Mixpanel Events",
+			fmt.Sprintf("%d of the events failed validation, the other %d were imported and are deduplicated when imported again.",
+				len(importErr.FailedRecords), imported),
+		)
+		return
+	}
+
+	plan.Id = types.StringValue(hex.EncodeToString(insertIds.Sum(nil)))
+	plan.ImportedCount = types.Int64Value(imported)
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update only stores the settings that do not need a new import.
+func (r *eventImportResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan EventImportModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete removes the resource from the Terraform state.
+func (r *eventImportResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Imported events cannot be deleted, they stay in the project
+}
+
+// loadConfigEvents decodes the events set in the configuration.
+func loadConfigEvents(models []EventModel, diags *diag.Diagnostics) []importedEvent {
+	events := make([]importedEvent, 0, len(models))
+	for i, model := range models {
+		event := importedEvent{index: i}
+		event.event.Event = model.Event.ValueString()
+
+		properties, err := decodeJSONObject(model.Properties.ValueString())
+		if err != nil {
+			addEventError(event, diags, "Invalid Mixpanel Event Properties", err.Error())
+			continue
+		}
+		event.event.Properties = properties
+
+		events = append(events, event)
+	}
+	return events
+}
+
+// loadFileEvents decodes the events of an NDJSON file and returns them with
+// the digest of the file.
+func loadFileEvents(file string, diags *diag.Diagnostics) ([]importedEvent, string) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		diags.AddAttributeError(path.Root("file"), "Unable to Read Events File", err.Error())
+		return nil, ""
+	}
+
+	sum := sha256.Sum256(content)
+
+	var events []importedEvent
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 0, 64*1024), maxEventLineSize)
+	for line := 1; scanner.Scan(); line++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+
+		event := importedEvent{index: -1, line: line}
+
+		document, err := decodeJSONDocument(scanner.Text())
+		object, isObject := document.(map[string]interface{})
+		if err == nil && !isObject {
+			err = fmt.Errorf("expected a JSON object")
+		}
+		if err != nil {
+			addEventError(event, diags, "Invalid Mixpanel Event", err.Error())
+			continue
+		}
+
+		name, _ := object["event"].(string)
+		properties, _ := object["properties"].(map[string]interface{})
+		if properties == nil {
+			properties = map[string]interface{}{}
+		}
+		event.event = mixpanel.Event{Event: name, Properties: properties}
+
+		events = append(events, event)
+	}
+	if err := scanner.Err(); err != nil {
+		diags.AddAttributeError(path.Root("file"), "Unable to Read Events File", err.Error())
+	}
+
+	return events, hex.EncodeToString(sum[:])
+}
+
+// addInsertId checks the $insert_id of an event, or derives one from the
+// project and the event so that importing it again is deduplicated.
+func addInsertId(projectId int64, event *importedEvent, diags *diag.Diagnostics) {
+	if insertId, ok := event.event.Properties["$insert_id"]; ok {
+		id, isString := insertId.(string)
+		if !isString {
+			addEventError(*event, diags, "Invalid Mixpanel Event Insert ID", "$insert_id must be a string")
+			return
+		}
+		if err := mixpanel.ValidateInsertId(id); err != nil {
+			addEventError(*event, diags, "Invalid Mixpanel Event Insert ID", err.Error())
+		}
+		return
+	}
+
+	properties, err := encodeJSONDocument(event.event.Properties)
+	if err != nil {
+		addEventError(*event, diags, "Invalid Mixpanel Event Properties", err.Error())
+		event.event.Properties["$insert_id"] = ""
+		return
+	}
+
+	event.event.Properties["$insert_id"] = deterministicId(strconv.FormatInt(projectId, 10), event.event.Event+"\n"+properties)
+}
+
+// addEventError reports an error on the attribute or the line the event was
+// defined at.
+func addEventError(event importedEvent, diags *diag.Diagnostics, summary, detail string) {
+	if event.index >= 0 {
+		diags.AddAttributeError(path.Root("events").AtListIndex(event.index), summary, detail)
+		return
+	}
+
+	diags.AddAttributeError(path.Root("file"), summary, fmt.Sprintf("Line %d: %s", event.line, detail))
+}
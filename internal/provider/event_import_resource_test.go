@@ -0,0 +1,169 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"terraform-provider-mixpanel/internal/mixpanel"
+	"terraform-provider-mixpanel/internal/mixpanel/mixpaneltest"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func testAccEventImportConfig(projectId int64, time string) string {
+	return fmt.Sprintf(`
+resource "mixpanel_event_import" "test" {
+  project_id = %d
+
+  events = [
+    {
+      event = "Sign Up"
+      properties = jsonencode({
+        time        = %s
+        distinct_id = "user-1"
+      })
+    },
+    {
+      event = "Purchase"
+      properties = jsonencode({
+        time        = 1700000100
+        distinct_id = "user-1"
+        "$insert_id" = "purchase-1"
+      })
+    },
+  ]
+}
+`, projectId, time)
+}
+
+// testAccCheckEventCount checks the number of events stored by Mixpanel.
+func testAccCheckEventCount(server *mixpaneltest.Server, projectId int64, count int) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		if stored := len(server.Events(projectId)); stored != count {
+			return fmt.Errorf("expected %d events in project %d, got %d", count, projectId, stored)
+		}
+		return nil
+	}
+}
+
+func TestAccEventImportResource(t *testing.T) {
+	server := testAccServer(t)
+	project := server.AddProject(mixpanel.Project{Name: "test"})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + testAccEventImportConfig(project.Id, "1700000000"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mixpanel_event_import.test", "imported_count", "2"),
+					testAccCheckEventCount(server, project.Id, 2),
+				),
+			},
+			// Only the changed event is new, the other one is deduplicated
+			{
+				Config: testAccProviderConfig(server) + testAccEventImportConfig(project.Id, "1700000001"),
+				Check:  testAccCheckEventCount(server, project.Id, 3),
+			},
+			// Strict mode errors point to the invalid event
+			{
+				Config:      testAccProviderConfig(server) + testAccEventImportConfig(project.Id, `"yesterday"`),
+				ExpectError: regexp.MustCompile(`Mixpanel rejected properties\.time`),
+			},
+		},
+	})
+}
+
+func TestAccEventImportResource_file(t *testing.T) {
+	server := testAccServer(t)
+	project := server.AddProject(mixpanel.Project{Name: "test"})
+
+	file := filepath.Join(t.TempDir(), "events.ndjson")
+	writeEvents := func(lines string) func() {
+		return func() {
+			if err := os.WriteFile(file, []byte(lines), 0o644); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	config := testAccProviderConfig(server) + fmt.Sprintf(`
+resource "mixpanel_event_import" "test" {
+  project_id = %d
+  file       = %q
+}
+`, project.Id, file)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: writeEvents(`{"event": "Sign Up", "properties": {"time": 1700000000, "distinct_id": "user-1"}}` + "\n"),
+				Config:    config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("mixpanel_event_import.test", "file_sha256"),
+					testAccCheckEventCount(server, project.Id, 1),
+				),
+			},
+			// A change of the file imports its events again
+			{
+				PreConfig: writeEvents(`{"event": "Sign Up", "properties": {"time": 1700000000, "distinct_id": "user-1"}}` + "\n" +
+					`{"event": "Sign Up", "properties": {"time": 1700000000, "distinct_id": "user-2"}}` + "\n"),
+				Config: config,
+				Check:  testAccCheckEventCount(server, project.Id, 2),
+			},
+		},
+	})
+}
+
+func TestLoadFileEvents(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "events.ndjson")
+	content := `{"event": "Sign Up", "properties": {"time": 1700000000, "distinct_id": "user-1"}}
+
+not json
+{"event": "Purchase", "properties": {"time": 1700000000, "distinct_id": "user-1", "$insert_id": "not valid!"}}
+`
+	if err := os.WriteFile(file, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var diags diag.Diagnostics
+	events, digest := loadFileEvents(file, &diags)
+	if digest == "" {
+		t.Error("expected the digest of the file")
+	}
+	if len(events) != 2 || events[0].line != 1 || events[1].line != 4 {
+		t.Fatalf("unexpected events: %+v", events)
+	}
+	if diags.ErrorsCount() != 1 || !regexp.MustCompile(`^Line 3: `).MatchString(diags.Errors()[0].Detail()) {
+		t.Errorf("expected an error on line 3, got %v", diags)
+	}
+
+	diags = nil
+	for i := range events {
+		addInsertId(1001, &events[i], &diags)
+	}
+	insertId, ok := events[0].event.Properties["$insert_id"].(string)
+	if !ok {
+		t.Fatalf("expected a derived $insert_id, got %v", events[0].event.Properties["$insert_id"])
+	}
+	if err := mixpanel.ValidateInsertId(insertId); err != nil {
+		t.Errorf("invalid derived $insert_id: %s", err)
+	}
+	if diags.ErrorsCount() != 1 || !regexp.MustCompile(`^Line 4: `).MatchString(diags.Errors()[0].Detail()) {
+		t.Errorf("expected an error on line 4, got %v", diags)
+	}
+
+	// The derived $insert_id only depends on the project and the event
+	again, _ := loadFileEvents(file, &diags)
+	addInsertId(1001, &again[0], &diags)
+	if again[0].event.Properties["$insert_id"] != events[0].event.Properties["$insert_id"] {
+		t.Error("expected the same $insert_id for the same event")
+	}
+}
@@ -0,0 +1,409 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-mixpanel/internal/mixpanel"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Status of a request whose task Mixpanel no longer knows, old tasks are
+// purged.
+const gdprRequestStatusExpired = "EXPIRED"
+
+// Interval between two status checks while waiting for a request to complete,
+// shortened by the tests.
+var gdprRequestPollInterval = 10 * time.Second
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &gdprRequestResource{}
+	_ resource.ResourceWithConfigure      = &gdprRequestResource{}
+	_ resource.ResourceWithValidateConfig = &gdprRequestResource{}
+)
+
+// NewGdprRequestResource is a helper function to simplify the provider implementation.
+func NewGdprRequestResource() resource.Resource {
+	return &gdprRequestResource{}
+}
+
+// gdprRequestResource is the resource implementation.
+type gdprRequestResource struct {
+	client *mixpanel.Client
+}
+
+type GdprRequestModel struct {
+	Id             types.String   `tfsdk:"id"`
+	ProjectId      types.Int64    `tfsdk:"project_id"`
+	DistinctIds    types.Set      `tfsdk:"distinct_ids"`
+	Type           types.String   `tfsdk:"type"`
+	ComplianceType types.String   `tfsdk:"compliance_type"`
+	WaitTimeout    types.String   `tfsdk:"wait_timeout"`
+	Status         types.String   `tfsdk:"status"`
+	Result         types.String   `tfsdk:"result"`
+	Timeouts       timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *gdprRequestResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*mixpanel.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *mixpanel.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// Metadata returns the resource type name.
+func (r *gdprRequestResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_gdpr_request"
+}
+
+// Schema defines the schema for the resource.
+func (r *gdprRequestResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "GDPR or CCPA data deletion or retrieval request. The request is submitted once on create, " +
+			"it is only submitted again when the resource is replaced. Destroying the resource only removes it from the state.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Task ID of the request.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_id": schema.Int64Attribute{
+				MarkdownDescription: "ID of the project holding the data.",
+				Required:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"distinct_ids": schema.SetAttribute{
+				MarkdownDescription: "Distinct IDs of the users the request is about.",
+				ElementType:         types.StringType,
+				Required:            true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.RequiresReplace(),
+				},
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Type of request, either `deletion` or `retrieval`.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(mixpanel.GdprRequestTypeDeletion, mixpanel.GdprRequestTypeRetrieval),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"compliance_type": schema.StringAttribute{
+				MarkdownDescription: "Regulation the request is filed under, either `GDPR` or `CCPA`. Default is `GDPR`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(mixpanel.GdprComplianceTypeGdpr),
+				Validators: []validator.String{
+					stringvalidator.OneOf(mixpanel.GdprComplianceTypeGdpr, mixpanel.GdprComplianceTypeCcpa),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"wait_timeout": schema.StringAttribute{
+				MarkdownDescription: "How long to wait for the request to complete, as a Go duration string. " +
+					"The resource is created with its current status when the timeout is reached. Default is `30m`, " +
+					"the wait also ends before the create timeout of the `timeouts` block expires.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("30m"),
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "Status of the request as reported by Mixpanel, e.g. `PENDING`, `SUCCESS` or `FAILURE`. " +
+					"`EXPIRED` once Mixpanel no longer knows the task.",
+				Computed: true,
+			},
+			"result": schema.StringAttribute{
+				MarkdownDescription: "Location of the exported data once a retrieval request succeeds.",
+				Computed:            true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+			}),
+		},
+	}
+}
+
+// ValidateConfig checks that wait_timeout is a valid duration.
+func (r *gdprRequestResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var waitTimeout types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("wait_timeout"), &waitTimeout)...)
+	if resp.Diagnostics.HasError() || waitTimeout.IsNull() || waitTimeout.IsUnknown() {
+		return
+	}
+
+	if _, err := time.ParseDuration(waitTimeout.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("wait_timeout"),
+			"Invalid Wait Timeout",
+			err.Error(),
+		)
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *gdprRequestResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state GdprRequestModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	project, err := r.client.GetProject(ctx, state.ProjectId.ValueInt64())
+	if err != nil {
+		if mixpanel.IsNotFound(err) {
+			// The project was deleted outside of Terraform, along with the data
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error Reading Mixpanel Project",
+			err.Error(),
+		)
+		return
+	}
+
+	request, err := r.client.GetGdprRequest(ctx, project.Domain, project.Token, state.Type.ValueString(), state.Id.ValueString())
+	if err != nil {
+		if mixpanel.IsNotFound(err) {
+			// Mixpanel purged the task. Submitting the request again takes
+			// an explicit replace, the state keeps the last result.
+			if state.Status.ValueString() != gdprRequestStatusExpired {
+				resp.Diagnostics.AddWarning(
+					"Mixpanel GDPR Request Expired",
+					"Mixpanel no longer knows GDPR request "+state.Id.ValueString()+", its status is now "+gdprRequestStatusExpired+". "+
+						"The request is not submitted again unless the resource is replaced.",
+				)
+			}
+			state.Status = types.StringValue(gdprRequestStatusExpired)
+
+			diags = resp.State.Set(ctx, &state)
+			resp.Diagnostics.Append(diags...)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error Reading Mixpanel GDPR Request",
+			"Could not read Mixpanel GDPR request "+state.Id.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	state.Status = types.StringValue(request.Status)
+	state.Result = types.StringValue(request.Result)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Create submits the request and waits for it to complete.
+func (r *gdprRequestResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan GdprRequestModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	waitTimeout, err := time.ParseDuration(plan.WaitTimeout.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("wait_timeout"),
+			"Invalid Wait Timeout",
+			err.Error(),
+		)
+		return
+	}
+
+	project, err := r.client.GetProject(ctx, plan.ProjectId.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Mixpanel Project",
+			err.Error(),
+		)
+		return
+	}
+
+	data := mixpanel.GdprRequest{
+		Type:           plan.Type.ValueString(),
+		ComplianceType: plan.ComplianceType.ValueString(),
+	}
+	resp.Diagnostics.Append(plan.DistinctIds.ElementsAs(ctx, &data.DistinctIds, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	created, err := r.client.CreateGdprRequest(ctx, project.Domain, project.Token, &data)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to create Mixpanel GDPR Request",
+			err.Error(),
+		)
+		return
+	}
+
+	// Save the task ID right away so the request is tracked even if waiting fails
+	plan.Id = types.StringValue(created.TaskId)
+	plan.Status = types.StringValue("")
+	plan.Result = types.StringValue("")
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.waitForRequest(ctx, project, &plan, waitTimeout, &resp.Diagnostics)
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update only stores the new wait_timeout and timeouts, every other attribute requires a replacement.
+func (r *gdprRequestResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan GdprRequestModel
+	var state GdprRequestModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.WaitTimeout = plan.WaitTimeout
+	state.Timeouts = plan.Timeouts
+
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete removes the resource from the Terraform state.
+func (r *gdprRequestResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Submitted requests cannot be reverted, there is nothing to do on the Mixpanel side
+}
+
+// waitForRequest refreshes the status of the request in plan until it
+// completes, or until wait_timeout is reached or ctx is about to expire. The
+// request is already submitted and saved in the state at this point, running
+// out of time, a failed status check or a failed request are only warnings:
+// an error would taint the resource and submit the request again on the next
+// apply.
+func (r *gdprRequestResource) waitForRequest(ctx context.Context, project *mixpanel.Project, plan *GdprRequestModel, waitTimeout time.Duration, diags *diag.Diagnostics) {
+	deadline := time.Now().Add(waitTimeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok {
+		// Keep time for the last status check
+		ctxDeadline = ctxDeadline.Add(-gdprRequestPollInterval)
+		if ctxDeadline.Before(deadline) {
+			deadline = ctxDeadline
+		}
+	}
+
+	stillInProgress := func() {
+		diags.AddWarning(
+			"Mixpanel GDPR Request Still In Progress",
+			fmt.Sprintf("GDPR request %s did not complete within wait_timeout or the create timeout, its last status was %q. "+
+				"The status will be refreshed on the next plan.", plan.Id.ValueString(), plan.Status.ValueString()),
+		)
+	}
+
+	for {
+		request, err := r.client.GetGdprRequest(ctx, project.Domain, project.Token, plan.Type.ValueString(), plan.Id.ValueString())
+		if err != nil {
+			if ctx.Err() != nil {
+				stillInProgress()
+				return
+			}
+
+			diags.AddWarning(
+				"Error Reading Mixpanel GDPR Request",
+				fmt.Sprintf("Could not read Mixpanel GDPR request %s, its last status was %q: %s. "+
+					"The status will be refreshed on the next plan.", plan.Id.ValueString(), plan.Status.ValueString(), err.Error()),
+			)
+			return
+		}
+
+		plan.Status = types.StringValue(request.Status)
+		plan.Result = types.StringValue(request.Result)
+
+		if request.Status == mixpanel.GdprRequestStatusFailure {
+			diags.AddWarning(
+				"Mixpanel GDPR Request Failed",
+				"Mixpanel reported a failure for GDPR request "+plan.Id.ValueString()+". "+
+					"Replace the resource to submit the request again.",
+			)
+			return
+		}
+
+		if request.Status == mixpanel.GdprRequestStatusSuccess {
+			return
+		}
+
+		if time.Now().Add(gdprRequestPollInterval).After(deadline) {
+			stillInProgress()
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			stillInProgress()
+			return
+		case <-time.After(gdprRequestPollInterval):
+		}
+	}
+}
@@ -0,0 +1,277 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"terraform-provider-mixpanel/internal/mixpanel"
+	"terraform-provider-mixpanel/internal/mixpanel/mixpaneltest"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+// testAccGdprPollInterval shortens the wait between two status checks for
+// the duration of a test.
+func testAccGdprPollInterval(t *testing.T) {
+	interval := gdprRequestPollInterval
+	gdprRequestPollInterval = 10 * time.Millisecond
+	t.Cleanup(func() { gdprRequestPollInterval = interval })
+}
+
+func testAccGdprRequestConfig(server *mixpaneltest.Server, projectId int64, waitTimeout string) string {
+	return testAccProviderConfig(server) + fmt.Sprintf(`
+resource "mixpanel_gdpr_request" "test" {
+  project_id   = %d
+  distinct_ids = ["user-1", "user-2"]
+  type         = "deletion"
+  wait_timeout = %q
+}
+`, projectId, waitTimeout)
+}
+
+// testAccCheckGdprStatusChecks checks the number of status checks of the
+// GDPR requests.
+func testAccCheckGdprStatusChecks(server *mixpaneltest.Server, count int) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		checks := 0
+		for _, request := range server.Requests() {
+			if request.Method == "GET" && strings.HasPrefix(request.Path, "/api/app/data-deletions/v3.0/task-") {
+				checks++
+			}
+		}
+		if checks != count {
+			return fmt.Errorf("expected %d status checks, got %d", count, checks)
+		}
+		return nil
+	}
+}
+
+func TestAccGdprRequestResource(t *testing.T) {
+	testAccGdprPollInterval(t)
+	server := testAccServer(t)
+	server.GdprPendingChecks = 2
+	project := server.AddProject(mixpanel.Project{Name: "test"})
+	var taskId string
+
+	// testAccCheckSubmittedOnce checks that the request was never submitted
+	// again
+	testAccCheckSubmittedOnce := func(*terraform.State) error {
+		if count := server.RequestCount("POST", "/api/app/data-deletions/v3.0/"); count != 1 {
+			return fmt.Errorf("expected a single submitted request, got %d", count)
+		}
+		return nil
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The request is polled until it succeeds
+			{
+				Config: testAccGdprRequestConfig(server, project.Id, "1m"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					func(s *terraform.State) error {
+						taskId = s.RootModule().Resources["mixpanel_gdpr_request.test"].Primary.ID
+						return nil
+					},
+					resource.TestMatchResourceAttr("mixpanel_gdpr_request.test", "id", regexp.MustCompile(`^task-\d+$`)),
+					resource.TestCheckResourceAttr("mixpanel_gdpr_request.test", "status", mixpanel.GdprRequestStatusSuccess),
+					resource.TestCheckResourceAttr("mixpanel_gdpr_request.test", "compliance_type", mixpanel.GdprComplianceTypeGdpr),
+					testAccCheckGdprStatusChecks(server, 3),
+				),
+			},
+			// Changing wait_timeout does not submit the request again
+			{
+				Config: testAccGdprRequestConfig(server, project.Id, "2m"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mixpanel_gdpr_request.test", "wait_timeout", "2m"),
+					testAccCheckSubmittedOnce,
+				),
+			},
+			// A task purged by Mixpanel is kept as expired, not submitted again
+			{
+				PreConfig: func() {
+					server.DeleteGdprRequest(taskId)
+				},
+				Config: testAccGdprRequestConfig(server, project.Id, "2m"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mixpanel_gdpr_request.test", "id", taskId),
+					resource.TestCheckResourceAttr("mixpanel_gdpr_request.test", "status", gdprRequestStatusExpired),
+					testAccCheckSubmittedOnce,
+				),
+			},
+			// A request of a project deleted outside of Terraform is removed from the state
+			{
+				PreConfig: func() {
+					server.DeleteProject(project.Id)
+				},
+				RefreshState:       true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccGdprRequestResource_failure(t *testing.T) {
+	testAccGdprPollInterval(t)
+	server := testAccServer(t)
+	server.GdprPendingChecks = 1
+	server.GdprStatus = mixpanel.GdprRequestStatusFailure
+	project := server.AddProject(mixpanel.Project{Name: "test"})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The failure is recorded, failing the create would taint the
+			// resource and submit the request again
+			{
+				Config: testAccGdprRequestConfig(server, project.Id, "1m"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mixpanel_gdpr_request.test", "status", mixpanel.GdprRequestStatusFailure),
+					resource.TestCheckResourceAttrSet("mixpanel_gdpr_request.test", "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccGdprRequestResource_waitTimeout(t *testing.T) {
+	testAccGdprPollInterval(t)
+	server := testAccServer(t)
+	server.GdprStatus = "PENDING"
+	project := server.AddProject(mixpanel.Project{Name: "test"})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// The resource is created with the last status once wait_timeout is reached
+			{
+				Config: testAccGdprRequestConfig(server, project.Id, "100ms"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mixpanel_gdpr_request.test", "status", "PENDING"),
+					resource.TestCheckResourceAttrSet("mixpanel_gdpr_request.test", "id"),
+				),
+			},
+			// The status is refreshed on the next plan
+			{
+				PreConfig: func() {
+					server.GdprStatus = mixpanel.GdprRequestStatusSuccess
+				},
+				Config: testAccGdprRequestConfig(server, project.Id, "100ms"),
+				Check:  resource.TestCheckResourceAttr("mixpanel_gdpr_request.test", "status", mixpanel.GdprRequestStatusSuccess),
+			},
+		},
+	})
+}
+
+func TestGdprRequestWaitDeadline(t *testing.T) {
+	testAccGdprPollInterval(t)
+	server := testAccServer(t)
+	server.GdprStatus = "PENDING"
+	project := server.AddProject(mixpanel.Project{Name: "test"})
+	r := &gdprRequestResource{client: testClient(t, server)}
+
+	created, err := r.client.CreateGdprRequest(context.Background(), project.Domain, project.Token, &mixpanel.GdprRequest{
+		Type:        mixpanel.GdprRequestTypeDeletion,
+		DistinctIds: []string{"user-1"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	plan := GdprRequestModel{
+		Id:     types.StringValue(created.TaskId),
+		Type:   types.StringValue(mixpanel.GdprRequestTypeDeletion),
+		Status: types.StringValue(""),
+		Result: types.StringValue(""),
+	}
+
+	// The create timeout is shorter than wait_timeout: waiting stops before
+	// it expires, with a warning rather than an error tainting the resource
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	var diags diag.Diagnostics
+	r.waitForRequest(ctx, &project, &plan, 30*time.Minute, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if ctx.Err() != nil {
+		t.Error("expected waiting to stop before the deadline")
+	}
+	if diags.WarningsCount() != 1 || diags.Warnings()[0].Summary() != "Mixpanel GDPR Request Still In Progress" {
+		t.Errorf("expected a single warning, got %v", diags)
+	}
+	if plan.Status.ValueString() != "PENDING" {
+		t.Errorf("expected the last status to be kept, got %s", plan.Status)
+	}
+
+	// An interrupted wait is not an error either
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+
+	diags = nil
+	r.waitForRequest(ctx, &project, &plan, 30*time.Minute, &diags)
+	if diags.HasError() || diags.WarningsCount() != 1 {
+		t.Errorf("expected a single warning, got %v", diags)
+	}
+}
+
+func TestGdprRequestWaitWarnings(t *testing.T) {
+	testAccGdprPollInterval(t)
+	server := testAccServer(t)
+	server.GdprStatus = mixpanel.GdprRequestStatusFailure
+	project := server.AddProject(mixpanel.Project{Name: "test"})
+	r := &gdprRequestResource{client: testClient(t, server)}
+
+	created, err := r.client.CreateGdprRequest(context.Background(), project.Domain, project.Token, &mixpanel.GdprRequest{
+		Type:        mixpanel.GdprRequestTypeDeletion,
+		DistinctIds: []string{"user-1"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	plan := GdprRequestModel{
+		Id:     types.StringValue(created.TaskId),
+		Type:   types.StringValue(mixpanel.GdprRequestTypeDeletion),
+		Status: types.StringValue(""),
+		Result: types.StringValue(""),
+	}
+
+	// A failed request is recorded in the status
+	var diags diag.Diagnostics
+	r.waitForRequest(context.Background(), &project, &plan, time.Minute, &diags)
+	if diags.HasError() || diags.WarningsCount() != 1 || diags.Warnings()[0].Summary() != "Mixpanel GDPR Request Failed" {
+		t.Errorf("expected a single warning, got %v", diags)
+	}
+	if plan.Status.ValueString() != mixpanel.GdprRequestStatusFailure {
+		t.Errorf("expected the failure to be recorded, got %s", plan.Status)
+	}
+
+	// A failed status check keeps the last status
+	server.Fail(mixpaneltest.Failure{
+		Method:     "GET",
+		Path:       "/api/app/data-deletions/v3.0/" + created.TaskId,
+		StatusCode: http.StatusForbidden,
+	})
+
+	diags = nil
+	r.waitForRequest(context.Background(), &project, &plan, time.Minute, &diags)
+	if diags.HasError() || diags.WarningsCount() != 1 || diags.Warnings()[0].Summary() != "Error Reading Mixpanel GDPR Request" {
+		t.Errorf("expected a single warning, got %v", diags)
+	}
+	if plan.Status.ValueString() != mixpanel.GdprRequestStatusFailure {
+		t.Errorf("expected the last status to be kept, got %s", plan.Status)
+	}
+}
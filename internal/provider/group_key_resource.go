@@ -0,0 +1,286 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"terraform-provider-mixpanel/internal/mixpanel"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &groupKeyResource{}
+	_ resource.ResourceWithConfigure   = &groupKeyResource{}
+	_ resource.ResourceWithImportState = &groupKeyResource{}
+)
+
+// NewGroupKeyResource is a helper function to simplify the provider implementation.
+func NewGroupKeyResource() resource.Resource {
+	return &groupKeyResource{}
+}
+
+// groupKeyResource is the resource implementation.
+type groupKeyResource struct {
+	client *mixpanel.Client
+}
+
+type GroupKeyModel struct {
+	Id           types.Int64    `tfsdk:"id"`
+	ProjectId    types.Int64    `tfsdk:"project_id"`
+	PropertyName types.String   `tfsdk:"property_name"`
+	DisplayName  types.String   `tfsdk:"display_name"`
+	Timeouts     timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *groupKeyResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*mixpanel.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *mixpanel.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// Metadata returns the resource type name.
+func (r *groupKeyResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_group_key"
+}
+
+// Schema defines the schema for the resource.
+func (r *groupKeyResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Group analytics identifier of a Mixpanel project, such as `company_id`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_id": schema.Int64Attribute{
+				MarkdownDescription: "ID of the project the group key is declared in.",
+				Required:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"property_name": schema.StringAttribute{
+				MarkdownDescription: "Event and profile property holding the group identifier.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"display_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the group shown in the Mixpanel UI.",
+				Required:            true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *groupKeyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state GroupKeyModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	groupKey, err := r.client.GetGroupKey(ctx, state.ProjectId.ValueInt64(), state.Id.ValueInt64())
+	if err != nil {
+		if mixpanel.IsNotFound(err) {
+			// The group key was deleted outside of Terraform, plan to create it again
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error Reading Mixpanel Group Key",
+			"Could not read Mixpanel group key ID "+strconv.FormatInt(state.Id.ValueInt64(), 10)+": "+err.Error(),
+		)
+		return
+	}
+
+	state = GroupKeyToGroupKeyModel(state.ProjectId.ValueInt64(), groupKey, state.Timeouts)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *groupKeyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan GroupKeyModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	data := mixpanel.GroupKey{
+		PropertyName: plan.PropertyName.ValueString(),
+		DisplayName:  plan.DisplayName.ValueString(),
+	}
+
+	groupKey, err := r.client.CreateGroupKey(ctx, plan.ProjectId.ValueInt64(), &data)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to create Mixpanel Group Key",
+			err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, GroupKeyToGroupKeyModel(plan.ProjectId.ValueInt64(), groupKey, plan.Timeouts))
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *groupKeyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan GroupKeyModel
+	var state GroupKeyModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only the display name can change, the other attributes require a replacement
+	err := r.client.UpdateGroupKeyDisplayName(ctx, state.ProjectId.ValueInt64(), state.Id.ValueInt64(), plan.DisplayName.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to update Mixpanel Group Key",
+			err.Error(),
+		)
+		return
+	}
+
+	groupKey, err := r.client.GetGroupKey(ctx, state.ProjectId.ValueInt64(), state.Id.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Mixpanel Group Key",
+			"Could not read Mixpanel group key ID "+strconv.FormatInt(state.Id.ValueInt64(), 10)+": "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, GroupKeyToGroupKeyModel(state.ProjectId.ValueInt64(), groupKey, plan.Timeouts))
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *groupKeyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state GroupKeyModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	err := r.client.DeleteGroupKey(ctx, state.ProjectId.ValueInt64(), state.Id.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to delete Mixpanel Group Key",
+			err.Error(),
+		)
+		return
+	}
+}
+
+func (r *groupKeyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ids, err := parseImportId(req.ID, "project_id", "id")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid ID",
+			err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), ids[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), ids[1])...)
+}
+
+func GroupKeyToGroupKeyModel(projectId int64, groupKey *mixpanel.GroupKey, modelTimeouts timeouts.Value) GroupKeyModel {
+	return GroupKeyModel{
+		Id:           types.Int64Value(groupKey.Id),
+		ProjectId:    types.Int64Value(projectId),
+		PropertyName: types.StringValue(groupKey.PropertyName),
+		DisplayName:  types.StringValue(groupKey.DisplayName),
+		Timeouts:     modelTimeouts,
+	}
+}
@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"terraform-provider-mixpanel/internal/mixpanel"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func testAccGroupKeyConfig(projectId int64, displayName string) string {
+	return fmt.Sprintf(`
+resource "mixpanel_group_key" "test" {
+  project_id    = %d
+  property_name = "company_id"
+  display_name  = %q
+}
+`, projectId, displayName)
+}
+
+func TestAccGroupKeyResource(t *testing.T) {
+	server := testAccServer(t)
+	project := server.AddProject(mixpanel.Project{Name: "test"})
+	var id int64
+
+	// testAccCheckGroupKeys checks the group keys of the project in Mixpanel
+	testAccCheckGroupKeys := func(expected ...mixpanel.GroupKey) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			groupKeys := server.GroupKeys(project.Id)
+			if len(groupKeys) != len(expected) {
+				return fmt.Errorf("expected group keys %+v, got %+v", expected, groupKeys)
+			}
+			for i := range expected {
+				expected[i].Id = id
+				if groupKeys[i] != expected[i] {
+					return fmt.Errorf("expected group keys %+v, got %+v", expected, groupKeys)
+				}
+			}
+			return nil
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccProviderConfig(server) + testAccGroupKeyConfig(project.Id, "Company"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccResourceId("mixpanel_group_key.test", &id),
+					resource.TestCheckResourceAttr("mixpanel_group_key.test", "property_name", "company_id"),
+					resource.TestCheckResourceAttr("mixpanel_group_key.test", "display_name", "Company"),
+					testAccCheckGroupKeys(mixpanel.GroupKey{PropertyName: "company_id", DisplayName: "Company"}),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName: "mixpanel_group_key.test",
+				ImportState:  true,
+				ImportStateIdFunc: func(*terraform.State) (string, error) {
+					return fmt.Sprintf("%d/%d", project.Id, id), nil
+				},
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"timeouts"},
+			},
+			// Update testing, the display name is changed in place
+			{
+				Config: testAccProviderConfig(server) + testAccGroupKeyConfig(project.Id, "Account"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mixpanel_group_key.test", "display_name", "Account"),
+					testAccCheckGroupKeys(mixpanel.GroupKey{PropertyName: "company_id", DisplayName: "Account"}),
+				),
+			},
+			// A group key deleted outside of Terraform is created again
+			{
+				PreConfig: func() {
+					server.DeleteGroupKey(project.Id, id)
+				},
+				RefreshState:       true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+		CheckDestroy: testAccCheckGroupKeys(),
+	})
+}
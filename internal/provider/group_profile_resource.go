@@ -0,0 +1,351 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"terraform-provider-mixpanel/internal/mixpanel"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &groupProfileResource{}
+	_ resource.ResourceWithConfigure      = &groupProfileResource{}
+	_ resource.ResourceWithImportState    = &groupProfileResource{}
+	_ resource.ResourceWithValidateConfig = &groupProfileResource{}
+)
+
+// NewGroupProfileResource is a helper function to simplify the provider implementation.
+func NewGroupProfileResource() resource.Resource {
+	return &groupProfileResource{}
+}
+
+// groupProfileResource is the resource implementation.
+type groupProfileResource struct {
+	client *mixpanel.Client
+}
+
+type GroupProfileModel struct {
+	Id         types.String   `tfsdk:"id"`
+	ProjectId  types.Int64    `tfsdk:"project_id"`
+	GroupKey   types.String   `tfsdk:"group_key"`
+	GroupId    types.String   `tfsdk:"group_id"`
+	Properties types.String   `tfsdk:"properties"`
+	Timeouts   timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *groupProfileResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*mixpanel.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *mixpanel.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// Metadata returns the resource type name.
+func (r *groupProfileResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_group_profile"
+}
+
+// Schema defines the schema for the resource.
+func (r *groupProfileResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Properties of a group profile, e.g. a B2B account, updated through the Groups Engage API. " +
+			"The group key must be configured on the project, e.g. with `mixpanel_group_key`. " +
+			"Only the properties set in `properties` are managed, changes made to them outside of Terraform are detected " +
+			"through the Engage query API. Destroying the resource removes these properties from the profile. " +
+			"Each refresh reads the profile with one request to the query API, limited to 60 requests per hour " +
+			"(see `requests_per_hour` of the provider). Past the first 60 profiles refreshed within an hour, " +
+			"each one waits about a minute for the limit.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Project ID, group key and group ID, separated by `/`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_id": schema.Int64Attribute{
+				MarkdownDescription: "ID of the project of the profile.",
+				Required:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"group_key": schema.StringAttribute{
+				MarkdownDescription: "Property name of the group key of the profile, e.g. `mixpanel_group_key.company.property_name`.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"group_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the group, i.e. the value of the group key property in its events.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"properties": schema.StringAttribute{
+				MarkdownDescription: "Properties set on the profile with `$set`, as a JSON object, e.g. with `jsonencode`. " +
+					"Properties removed from the object are removed from the profile.",
+				Required: true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// ValidateConfig checks the properties of the profile.
+func (r *groupProfileResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config GroupProfileModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateProfileConfig(ctx, config.Properties, types.StringNull(), types.SetNull(types.StringType), &resp.Diagnostics)
+}
+
+// Read refreshes the managed properties with the profile in Mixpanel.
+func (r *groupProfileResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state GroupProfileModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	properties, found := readProfile(ctx, r.client, state.ProjectId.ValueInt64(), state.target(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.Id = types.StringValue(groupProfileId(state.ProjectId.ValueInt64(), state.GroupKey.ValueString(), state.GroupId.ValueString()))
+	state.Properties = refreshProfileProperties(state.Properties, properties, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Create updates the profile and sets the initial Terraform state.
+func (r *groupProfileResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan GroupProfileModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	r.update(ctx, plan, types.StringNull(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.Id = types.StringValue(groupProfileId(plan.ProjectId.ValueInt64(), plan.GroupKey.ValueString(), plan.GroupId.ValueString()))
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the profile and sets the updated Terraform state on success.
+func (r *groupProfileResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan GroupProfileModel
+	var state GroupProfileModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	r.update(ctx, plan, state.Properties, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete removes the managed properties from the profile.
+func (r *groupProfileResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state GroupProfileModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	deleteProfile(ctx, r.client, state.ProjectId.ValueInt64(), state.target(), state.Properties, &resp.Diagnostics)
+}
+
+func (r *groupProfileResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// The group ID may contain "/", only the first two separate the parts
+	parts := strings.SplitN(req.ID, "/", 3)
+	if len(parts) != 3 || parts[1] == "" || parts[2] == "" {
+		resp.Diagnostics.AddError(
+			"Invalid ID",
+			fmt.Sprintf("expected import identifier with format project_id/group_key/group_id, got %q", req.ID),
+		)
+		return
+	}
+
+	projectId, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid ID",
+			fmt.Sprintf("expected import identifier with format project_id/group_key/group_id, got %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), projectId)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("group_key"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("group_id"), parts[2])...)
+}
+
+// update applies the plan to the profile, removing the properties no longer
+// set since previous.
+func (r *groupProfileResource) update(ctx context.Context, plan GroupProfileModel, previous types.String, diags *diag.Diagnostics) {
+	update := profileUpdate(ctx, plan.Properties, previous, types.StringNull(), types.SetNull(types.StringType), diags)
+	if diags.HasError() {
+		return
+	}
+
+	// Mixpanel accepts updates of unknown group keys, but the profiles
+	// cannot be queried until the group key is configured
+	_, err := r.client.GetGroupKeyByPropertyName(ctx, plan.ProjectId.ValueInt64(), plan.GroupKey.ValueString())
+	if err != nil {
+		if mixpanel.IsNotFound(err) {
+			diags.AddAttributeError(
+				path.Root("group_key"),
+				"Mixpanel Group Key Not Configured",
+				fmt.Sprintf("Property %q is not a group key of project %d, configure it first, e.g. with the mixpanel_group_key resource.",
+					plan.GroupKey.ValueString(), plan.ProjectId.ValueInt64()),
+			)
+			return
+		}
+
+		diags.AddError(
+			"Error Reading Mixpanel Group Key",
+			err.Error(),
+		)
+		return
+	}
+
+	updateProfile(ctx, r.client, plan.ProjectId.ValueInt64(), plan.target(), update, diags)
+}
+
+// target returns the profile of the group of the model.
+func (m GroupProfileModel) target() groupProfileTarget {
+	return groupProfileTarget{groupKey: m.GroupKey.ValueString(), groupId: m.GroupId.ValueString()}
+}
+
+// groupProfileTarget is the profile of the group groupId of the group key
+// groupKey, a property name.
+type groupProfileTarget struct {
+	groupKey string
+	groupId  string
+}
+
+func (t groupProfileTarget) kind() string {
+	return "Group Profile"
+}
+
+func (t groupProfileTarget) String() string {
+	return "the profile of group " + t.groupId
+}
+
+// get returns a NotFoundError when the group key is deleted, its profiles
+// cannot be queried anymore.
+func (t groupProfileTarget) get(ctx context.Context, client *mixpanel.Client, project *mixpanel.Project) (map[string]interface{}, error) {
+	groupKey, err := client.GetGroupKeyByPropertyName(ctx, project.Id, t.groupKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.GetGroupProfile(ctx, project.Id, project.Domain, groupKey.Id, t.groupId)
+}
+
+func (t groupProfileTarget) update(ctx context.Context, client *mixpanel.Client, project *mixpanel.Project, update mixpanel.ProfileUpdate) error {
+	return client.UpdateGroupProfile(ctx, project.Domain, project.Token, t.groupKey, t.groupId, update)
+}
+
+func groupProfileId(projectId int64, groupKey, groupId string) string {
+	return fmt.Sprintf("%d/%s/%s", projectId, groupKey, groupId)
+}
@@ -0,0 +1,126 @@
+package provider
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"terraform-provider-mixpanel/internal/mixpanel"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func testAccGroupProfileConfig(projectId int64, plan string) string {
+	return fmt.Sprintf(`
+resource "mixpanel_group_key" "company" {
+  project_id    = %[1]d
+  property_name = "company_id"
+  display_name  = "Company"
+}
+
+resource "mixpanel_group_profile" "test" {
+  project_id = %[1]d
+  group_key  = mixpanel_group_key.company.property_name
+  group_id   = "acme"
+
+  properties = jsonencode({
+    "$name" = "Acme"
+    plan    = %[2]q
+    seats   = 50
+  })
+}
+`, projectId, plan)
+}
+
+func TestAccGroupProfileResource(t *testing.T) {
+	server := testAccServer(t)
+	project := server.AddProject(mixpanel.Project{Name: "test"})
+	server.SetGroupProfile(project.Id, "company_id", "acme", map[string]interface{}{"industry": "retail"})
+
+	// testAccCheckProfile checks the properties of the profile in Mixpanel
+	testAccCheckProfile := func(expected map[string]interface{}) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			profile, _ := server.GroupProfile(project.Id, "company_id", "acme")
+			if !reflect.DeepEqual(profile, expected) {
+				return fmt.Errorf("expected profile %v, got %v", expected, profile)
+			}
+			return nil
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + testAccGroupProfileConfig(project.Id, "enterprise"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mixpanel_group_profile.test", "id", fmt.Sprintf("%d/company_id/acme", project.Id)),
+					testAccCheckProfile(map[string]interface{}{
+						"$name":    "Acme",
+						"plan":     "enterprise",
+						"seats":    float64(50),
+						"industry": "retail",
+					}),
+				),
+			},
+			// ImportState testing, only the identifiers can be imported
+			{
+				ResourceName:            "mixpanel_group_profile.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"properties", "timeouts"},
+			},
+			// Changes made outside of Terraform are reverted
+			{
+				PreConfig: func() {
+					server.SetGroupProfile(project.Id, "company_id", "acme", map[string]interface{}{"plan": "free", "industry": "retail"})
+				},
+				Config: testAccProviderConfig(server) + testAccGroupProfileConfig(project.Id, "enterprise"),
+				Check: testAccCheckProfile(map[string]interface{}{
+					"$name":    "Acme",
+					"plan":     "enterprise",
+					"seats":    float64(50),
+					"industry": "retail",
+				}),
+			},
+			// Update testing
+			{
+				Config: testAccProviderConfig(server) + testAccGroupProfileConfig(project.Id, "growth"),
+				Check: testAccCheckProfile(map[string]interface{}{
+					"$name":    "Acme",
+					"plan":     "growth",
+					"seats":    float64(50),
+					"industry": "retail",
+				}),
+			},
+		},
+		// Destroying the resource removes the managed properties
+		CheckDestroy: testAccCheckProfile(map[string]interface{}{"industry": "retail"}),
+	})
+}
+
+func TestAccGroupProfileResource_unknownGroupKey(t *testing.T) {
+	server := testAccServer(t)
+	project := server.AddProject(mixpanel.Project{Name: "test"})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + fmt.Sprintf(`
+resource "mixpanel_group_profile" "test" {
+  project_id = %d
+  group_key  = "company_id"
+  group_id   = "acme"
+  properties = jsonencode({ plan = "enterprise" })
+}
+`, project.Id),
+				ExpectError: regexp.MustCompile(`Mixpanel Group Key Not Configured`),
+			},
+		},
+	})
+}
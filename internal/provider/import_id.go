@@ -0,0 +1,27 @@
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// parseImportId splits a "/" separated import identifier into its numeric
+// parts, one per name given, e.g. "123/456" for ("project_id", "id").
+func parseImportId(id string, names ...string) ([]int64, error) {
+	parts := strings.Split(id, "/")
+	if len(parts) != len(names) {
+		return nil, fmt.Errorf("expected import identifier with format %s, got %q", strings.Join(names, "/"), id)
+	}
+
+	values := make([]int64, len(parts))
+	for i, part := range parts {
+		value, err := strconv.ParseInt(part, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be an integer, got %q", names[i], part)
+		}
+		values[i] = value
+	}
+
+	return values, nil
+}
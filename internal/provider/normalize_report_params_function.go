@@ -0,0 +1,205 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &normalizeReportParamsFunction{}
+
+// Keys the Mixpanel UI stores in report params to restore its layout. They
+// do not change the report and are stripped at any depth.
+var reportParamsUiKeys = map[string]bool{
+	"columnWidths": true,
+	"isCollapsed":  true,
+	"isExpanded":   true,
+	"hiddenSeries": true,
+	"scrollState":  true,
+}
+
+// NewNormalizeReportParamsFunction is a helper function to simplify the provider implementation.
+func NewNormalizeReportParamsFunction() function.Function {
+	return &normalizeReportParamsFunction{}
+}
+
+// normalizeReportParamsFunction canonicalizes report (bookmark) params.
+type normalizeReportParamsFunction struct{}
+
+func (f *normalizeReportParamsFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "normalize_report_params"
+}
+
+func (f *normalizeReportParamsFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Canonicalize the params of a Mixpanel report",
+		MarkdownDescription: "Canonicalizes the JSON params of a Mixpanel report (bookmark): object keys are sorted, " +
+			"whitespace is removed and the fields only used by the UI layout (`" + strings.Join(sortedKeys(reportParamsUiKeys), "`, `") + "`) are stripped, " +
+			"so that documents exported from the UI compare equal to the ones written by hand. " +
+			"Fails when the params are not a JSON object.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "json",
+				MarkdownDescription: "Report params as a JSON document.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *normalizeReportParamsFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var document string
+
+	resp.Error = req.Arguments.Get(ctx, &document)
+	if resp.Error != nil {
+		return
+	}
+
+	normalized, err := normalizeReportParams(document)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, normalized)
+}
+
+func normalizeReportParams(document string) (string, error) {
+	value, err := decodeJSONDocument(document)
+	if err != nil {
+		return "", err
+	}
+
+	if _, ok := value.(map[string]interface{}); !ok {
+		return "", fmt.Errorf("report params must be a JSON object")
+	}
+
+	return encodeJSONDocument(stripKeys(value, reportParamsUiKeys))
+}
+
+// decodeJSONDocument decodes a single JSON value, keeping numbers as written.
+func decodeJSONDocument(document string) (interface{}, error) {
+	decoder := json.NewDecoder(strings.NewReader(document))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	// More reports false before a closing delimiter, so anything but the end
+	// of the input is rejected
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, fmt.Errorf("invalid JSON: unexpected data after the document")
+	}
+
+	return value, nil
+}
+
+// decodeJSONObject decodes a JSON object, keeping numbers as written.
+func decodeJSONObject(document string) (map[string]interface{}, error) {
+	value, err := decodeJSONDocument(document)
+	if err != nil {
+		return nil, err
+	}
+
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected a JSON object")
+	}
+
+	return object, nil
+}
+
+// jsonValuesEqual reports whether two values decoded by decodeJSONDocument
+// are equal. Numbers are compared by value, so that 1 and 1.0 are the same.
+func jsonValuesEqual(a, b interface{}) bool {
+	switch a := a.(type) {
+	case json.Number:
+		b, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		x, okX := new(big.Rat).SetString(a.String())
+		y, okY := new(big.Rat).SetString(b.String())
+		if !okX || !okY {
+			return a == b
+		}
+		return x.Cmp(y) == 0
+	case map[string]interface{}:
+		b, ok := b.(map[string]interface{})
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for key, value := range a {
+			other, ok := b[key]
+			if !ok || !jsonValuesEqual(value, other) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		b, ok := b.([]interface{})
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if !jsonValuesEqual(a[i], b[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return a == b
+	}
+}
+
+// encodeJSONDocument encodes a value compactly with sorted object keys.
+func encodeJSONDocument(value interface{}) (string, error) {
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+
+	if err := encoder.Encode(value); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(buffer.String(), "\n"), nil
+}
+
+func stripKeys(value interface{}, keys map[string]bool) interface{} {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, item := range value {
+			if keys[key] {
+				delete(value, key)
+			} else {
+				value[key] = stripKeys(item, keys)
+			}
+		}
+		return value
+	case []interface{}:
+		for i, item := range value {
+			value[i] = stripKeys(item, keys)
+		}
+		return value
+	default:
+		return value
+	}
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,40 @@
+package provider
+
+import (
+	"testing"
+)
+
+func TestNormalizeReportParams(t *testing.T) {
+	exported := `{
+  "sections": {"show": [{"metric": "total", "value": {"name": "Sign Up"}}], "columnWidths": {"0": 120}},
+  "displayOptions": {"chartType": "line", "isCollapsed": false},
+  "count": 10000000000000001,
+  "filter": "<a & b>"
+}`
+	handWritten := `{"count":10000000000000001,"displayOptions":{"chartType":"line"},"filter":"<a & b>","sections":{"show":[{"value":{"name":"Sign Up"},"metric":"total"}]}}`
+
+	normalizedExported, err := normalizeReportParams(exported)
+	if err != nil {
+		t.Fatal(err)
+	}
+	normalizedHandWritten, err := normalizeReportParams(handWritten)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"count":10000000000000001,"displayOptions":{"chartType":"line"},"filter":"<a & b>","sections":{"show":[{"metric":"total","value":{"name":"Sign Up"}}]}}`
+	if normalizedExported != want {
+		t.Errorf("got %s, want %s", normalizedExported, want)
+	}
+	if normalizedHandWritten != want {
+		t.Errorf("got %s, want %s", normalizedHandWritten, want)
+	}
+}
+
+func TestNormalizeReportParamsInvalid(t *testing.T) {
+	for _, document := range []string{`[]`, `{"a": 1`, `{} {}`, `{"a": 1}}`, `{"a": 1}]`, `{"a": 1} x`, `"params"`} {
+		if _, err := normalizeReportParams(document); err == nil {
+			t.Errorf("expected %s to be rejected", document)
+		}
+	}
+}
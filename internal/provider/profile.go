@@ -0,0 +1,282 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"terraform-provider-mixpanel/internal/mixpanel"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// profileTarget is the profile managed by mixpanel_user_profile or
+// mixpanel_group_profile, for the operations both resources share.
+type profileTarget interface {
+	// kind names the profile in the diagnostics, e.g. "User Profile".
+	kind() string
+	// String describes the profile in the diagnostics.
+	String() string
+	// get returns the properties of the profile, or a NotFoundError.
+	get(ctx context.Context, client *mixpanel.Client, project *mixpanel.Project) (map[string]interface{}, error)
+	// update applies update to the profile, client authenticates with the
+	// project secret.
+	update(ctx context.Context, client *mixpanel.Client, project *mixpanel.Project, update mixpanel.ProfileUpdate) error
+}
+
+// readProfile returns the properties of target, found is false when the
+// project or the profile no longer exist.
+func readProfile(ctx context.Context, client *mixpanel.Client, projectId int64, target profileTarget, diags *diag.Diagnostics) (properties map[string]interface{}, found bool) {
+	project, err := client.GetProject(ctx, projectId)
+	if err != nil {
+		if mixpanel.IsNotFound(err) {
+			return nil, false
+		}
+
+		diags.AddError(
+			"Error Reading Mixpanel Project",
+			"Could not read Mixpanel project ID "+strconv.FormatInt(projectId, 10)+": "+err.Error(),
+		)
+		return nil, false
+	}
+
+	properties, err = target.get(ctx, client, project)
+	if err != nil {
+		if mixpanel.IsNotFound(err) {
+			// The profile was deleted outside of Terraform, plan to update it again
+			return nil, false
+		}
+
+		diags.AddError(
+			"Error Reading Mixpanel "+target.kind(),
+			"Could not read "+target.String()+": "+err.Error(),
+		)
+		return nil, false
+	}
+
+	return properties, true
+}
+
+// updateProfile applies update to target.
+func updateProfile(ctx context.Context, client *mixpanel.Client, projectId int64, target profileTarget, update mixpanel.ProfileUpdate, diags *diag.Diagnostics) {
+	project, err := client.GetProject(ctx, projectId)
+	if err != nil {
+		diags.AddError(
+			"Error Reading Mixpanel Project",
+			err.Error(),
+		)
+		return
+	}
+
+	sendProfileUpdate(ctx, client, project, target, update, diags)
+}
+
+// deleteProfile removes the properties managed in properties from target.
+func deleteProfile(ctx context.Context, client *mixpanel.Client, projectId int64, target profileTarget, properties types.String, diags *diag.Diagnostics) {
+	update := mixpanel.ProfileUpdate{Unset: profilePropertyNames(properties, diags)}
+	if diags.HasError() || len(update.Unset) == 0 {
+		return
+	}
+
+	project, err := client.GetProject(ctx, projectId)
+	if err != nil {
+		if mixpanel.IsNotFound(err) {
+			return
+		}
+
+		diags.AddError(
+			"Error Reading Mixpanel Project",
+			err.Error(),
+		)
+		return
+	}
+
+	sendProfileUpdate(ctx, client, project, target, update, diags)
+}
+
+func sendProfileUpdate(ctx context.Context, client *mixpanel.Client, project *mixpanel.Project, target profileTarget, update mixpanel.ProfileUpdate, diags *diag.Diagnostics) {
+	ingestionClient := client.WithAuthenticator(&mixpanel.ProjectSecretAuthenticator{Secret: project.Secret})
+	err := target.update(ctx, ingestionClient, project, update)
+	if err != nil {
+		diags.AddError(
+			"Unable to update Mixpanel "+target.kind(),
+			err.Error(),
+		)
+	}
+}
+
+func profilePropertiesAttribute(description string) schema.StringAttribute {
+	return schema.StringAttribute{
+		MarkdownDescription: description,
+		Optional:            true,
+	}
+}
+
+func profileUnsetAttribute() schema.SetAttribute {
+	return schema.SetAttribute{
+		MarkdownDescription: "Names of the properties removed from the profile with `$unset`. " +
+			"They are removed again when they are set outside of Terraform.",
+		ElementType: types.StringType,
+		Optional:    true,
+	}
+}
+
+// validateProfileConfig checks that the known properties are JSON objects
+// and that no property is both set and unset.
+func validateProfileConfig(ctx context.Context, properties, setOnce types.String, unset types.Set, diags *diag.Diagnostics) {
+	set := make(map[string]bool)
+	for name, value := range map[string]types.String{"properties": properties, "set_once": setOnce} {
+		if value.IsNull() || value.IsUnknown() {
+			continue
+		}
+
+		object, err := decodeJSONObject(value.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root(name), "Invalid Mixpanel Profile Properties", err.Error())
+			continue
+		}
+		for key := range object {
+			set[key] = true
+		}
+	}
+
+	if unset.IsNull() || unset.IsUnknown() {
+		return
+	}
+
+	var names []types.String
+	diags.Append(unset.ElementsAs(ctx, &names, false)...)
+	for _, name := range names {
+		if set[name.ValueString()] {
+			diags.AddAttributeError(
+				path.Root("unset"),
+				"Conflicting Mixpanel Profile Properties",
+				fmt.Sprintf("Property %q is both set and unset.", name.ValueString()),
+			)
+		}
+	}
+}
+
+// profileUpdate builds the operations applying properties, setOnce and unset
+// to a profile, removing the properties of previous no longer in properties.
+func profileUpdate(ctx context.Context, properties, previous, setOnce types.String, unset types.Set, diags *diag.Diagnostics) mixpanel.ProfileUpdate {
+	var update mixpanel.ProfileUpdate
+
+	if !properties.IsNull() {
+		object, err := decodeJSONObject(properties.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("properties"), "Invalid Mixpanel Profile Properties", err.Error())
+			return update
+		}
+		update.Set = object
+	}
+
+	if !setOnce.IsNull() {
+		object, err := decodeJSONObject(setOnce.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("set_once"), "Invalid Mixpanel Profile Properties", err.Error())
+			return update
+		}
+		update.SetOnce = object
+	}
+
+	if !unset.IsNull() {
+		diags.Append(unset.ElementsAs(ctx, &update.Unset, false)...)
+	}
+
+	for _, name := range profilePropertyNames(previous, diags) {
+		if _, ok := update.Set[name]; !ok {
+			update.Unset = append(update.Unset, name)
+		}
+	}
+
+	return update
+}
+
+// profilePropertyNames returns the sorted names of a JSON object of
+// properties.
+func profilePropertyNames(properties types.String, diags *diag.Diagnostics) []string {
+	if properties.IsNull() || properties.IsUnknown() {
+		return nil
+	}
+
+	object, err := decodeJSONObject(properties.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("properties"), "Invalid Mixpanel Profile Properties", err.Error())
+		return nil
+	}
+
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// refreshProfileProperties returns the values in actual of the properties
+// managed in current. current is kept when the values are the same, so that
+// its formatting does not show as a change.
+func refreshProfileProperties(current types.String, actual map[string]interface{}, diags *diag.Diagnostics) types.String {
+	if current.IsNull() {
+		return current
+	}
+
+	managed, err := decodeJSONObject(current.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("properties"), "Invalid Mixpanel Profile Properties", err.Error())
+		return current
+	}
+
+	refreshed := make(map[string]interface{}, len(managed))
+	for name := range managed {
+		if value, ok := actual[name]; ok {
+			refreshed[name] = value
+		}
+	}
+
+	// Decode the values the same way as the configuration before comparing them
+	document, err := encodeJSONDocument(refreshed)
+	if err == nil {
+		refreshed, err = decodeJSONObject(document)
+	}
+	if err != nil {
+		diags.AddAttributeError(path.Root("properties"), "Invalid Mixpanel Profile Properties", err.Error())
+		return current
+	}
+
+	if jsonValuesEqual(managed, refreshed) {
+		return current
+	}
+
+	return types.StringValue(document)
+}
+
+// refreshProfileUnset returns unset without the properties the profile has
+// again, so that they are planned to be removed.
+func refreshProfileUnset(ctx context.Context, unset types.Set, actual map[string]interface{}, diags *diag.Diagnostics) types.Set {
+	if unset.IsNull() {
+		return unset
+	}
+
+	var names []string
+	diags.Append(unset.ElementsAs(ctx, &names, false)...)
+
+	absent := make([]string, 0, len(names))
+	for _, name := range names {
+		if _, ok := actual[name]; !ok {
+			absent = append(absent, name)
+		}
+	}
+
+	if len(absent) == len(names) {
+		return unset
+	}
+
+	refreshed, d := types.SetValueFrom(ctx, types.StringType, absent)
+	diags.Append(d...)
+	return refreshed
+}
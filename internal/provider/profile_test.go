@@ -0,0 +1,120 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"terraform-provider-mixpanel/internal/mixpanel"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestProfileOperations(t *testing.T) {
+	server := testAccServer(t)
+	project := server.AddProject(mixpanel.Project{Name: "test"})
+	client := testClient(t, server)
+	ctx := context.Background()
+
+	if _, err := client.CreateGroupKey(ctx, project.Id, &mixpanel.GroupKey{PropertyName: "company_id", DisplayName: "Company"}); err != nil {
+		t.Fatal(err)
+	}
+
+	targets := map[string]profileTarget{
+		"user":  userProfileTarget{distinctId: "qa-1"},
+		"group": groupProfileTarget{groupKey: "company_id", groupId: "acme"},
+	}
+
+	for name, target := range targets {
+		t.Run(name, func(t *testing.T) {
+			var diags diag.Diagnostics
+
+			if _, found := readProfile(ctx, client, project.Id, target, &diags); found {
+				t.Fatal("expected the profile not to be found")
+			}
+
+			updateProfile(ctx, client, project.Id, target, mixpanel.ProfileUpdate{
+				Set: map[string]interface{}{"plan": "enterprise", "seats": 3},
+			}, &diags)
+			updateProfile(ctx, client, project.Id, target, mixpanel.ProfileUpdate{
+				Set: map[string]interface{}{"owner": "sales"},
+			}, &diags)
+
+			properties, found := readProfile(ctx, client, project.Id, target, &diags)
+			expected := map[string]interface{}{"plan": "enterprise", "seats": float64(3), "owner": "sales"}
+			if !found || !reflect.DeepEqual(properties, expected) {
+				t.Errorf("expected %v, got %v", expected, properties)
+			}
+
+			// Only the managed properties are removed
+			deleteProfile(ctx, client, project.Id, target, types.StringValue(`{"plan": "enterprise", "seats": 3}`), &diags)
+
+			properties, _ = readProfile(ctx, client, project.Id, target, &diags)
+			expected = map[string]interface{}{"owner": "sales"}
+			if !reflect.DeepEqual(properties, expected) {
+				t.Errorf("expected %v, got %v", expected, properties)
+			}
+
+			// Nothing is left to remove from the profiles of a deleted project
+			if _, found := readProfile(ctx, client, 0, target, &diags); found {
+				t.Error("expected the profile of an unknown project not to be found")
+			}
+			deleteProfile(ctx, client, 0, target, types.StringValue(`{"owner": "sales"}`), &diags)
+
+			if diags.HasError() {
+				t.Errorf("unexpected diagnostics: %v", diags)
+			}
+		})
+	}
+}
+
+func TestRefreshProfileProperties(t *testing.T) {
+	var diags diag.Diagnostics
+
+	// Formatting differences are not changes
+	current := types.StringValue(`{ "seats": 3, "role": "qa" }`)
+	refreshed := refreshProfileProperties(current, map[string]interface{}{"role": "qa", "seats": float64(3), "other": true}, &diags)
+	if !refreshed.Equal(current) {
+		t.Errorf("expected %s to be kept, got %s", current, refreshed)
+	}
+
+	// Changed and removed properties are reported, other properties ignored
+	refreshed = refreshProfileProperties(current, map[string]interface{}{"role": "admin", "other": true}, &diags)
+	if refreshed.ValueString() != `{"role":"admin"}` {
+		t.Errorf("unexpected refreshed properties %s", refreshed)
+	}
+
+	// Numbers are compared by value, whatever their formatting
+	current = types.StringValue(`{"seats": 3.0, "ratio": 1e2, "tags": [1.50, "a"], "plan": {"level": 2.0}}`)
+	refreshed = refreshProfileProperties(current, map[string]interface{}{
+		"seats": float64(3),
+		"ratio": float64(100),
+		"tags":  []interface{}{1.5, "a"},
+		"plan":  map[string]interface{}{"level": float64(2)},
+	}, &diags)
+	if !refreshed.Equal(current) {
+		t.Errorf("expected %s to be kept, got %s", current, refreshed)
+	}
+
+	refreshed = refreshProfileProperties(current, map[string]interface{}{
+		"seats": float64(4),
+		"ratio": float64(100),
+		"tags":  []interface{}{1.5, "a"},
+		"plan":  map[string]interface{}{"level": float64(2)},
+	}, &diags)
+	if refreshed.ValueString() != `{"plan":{"level":2},"ratio":100,"seats":4,"tags":[1.5,"a"]}` {
+		t.Errorf("unexpected refreshed properties %s", refreshed)
+	}
+
+	unset, _ := types.SetValueFrom(context.Background(), types.StringType, []string{"legacy_plan", "trial"})
+	refreshedUnset := refreshProfileUnset(context.Background(), unset, map[string]interface{}{"trial": true}, &diags)
+	expectedUnset, _ := types.SetValueFrom(context.Background(), types.StringType, []string{"legacy_plan"})
+	if !refreshedUnset.Equal(expectedUnset) {
+		t.Errorf("expected %s, got %s", expectedUnset, refreshedUnset)
+	}
+
+	if diags.HasError() {
+		t.Errorf("unexpected diagnostics: %v", diags)
+	}
+}
@@ -0,0 +1,118 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"terraform-provider-mixpanel/internal/mixpanel"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ ephemeral.EphemeralResource              = &projectCredentialsEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure = &projectCredentialsEphemeralResource{}
+)
+
+// NewProjectCredentialsEphemeralResource is a helper function to simplify the provider implementation.
+func NewProjectCredentialsEphemeralResource() ephemeral.EphemeralResource {
+	return &projectCredentialsEphemeralResource{}
+}
+
+// projectCredentialsEphemeralResource is the ephemeral resource implementation.
+type projectCredentialsEphemeralResource struct {
+	client *mixpanel.Client
+}
+
+type ProjectCredentialsModel struct {
+	ProjectId types.Int64  `tfsdk:"project_id"`
+	ApiKey    types.String `tfsdk:"api_key"`
+	Token     types.String `tfsdk:"token"`
+	Secret    types.String `tfsdk:"secret"`
+}
+
+// Configure adds the provider configured client to the ephemeral resource.
+func (r *projectCredentialsEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*mixpanel.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *mixpanel.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// Metadata returns the ephemeral resource type name.
+func (r *projectCredentialsEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_project_credentials"
+}
+
+// Schema defines the schema for the ephemeral resource.
+func (r *projectCredentialsEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Fetches the credentials of a Mixpanel project when Terraform needs them, without storing them in the plan or the state. " +
+			"Use it to pass the credentials to ephemeral or write-only arguments of other providers, e.g. a Vault or Kubernetes secret. " +
+			"Requires Terraform 1.10 or later.",
+		Attributes: map[string]schema.Attribute{
+			"project_id": schema.Int64Attribute{
+				MarkdownDescription: "ID of the project.",
+				Required:            true,
+			},
+			"api_key": schema.StringAttribute{
+				MarkdownDescription: "API key of the project.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"token": schema.StringAttribute{
+				MarkdownDescription: "Token of the project, used to send events.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"secret": schema.StringAttribute{
+				MarkdownDescription: "API secret of the project.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+		},
+	}
+}
+
+// Open fetches the credentials of the project.
+func (r *projectCredentialsEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data ProjectCredentialsModel
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	project, err := r.client.GetProject(ctx, data.ProjectId.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Mixpanel Project",
+			"Could not read Mixpanel project ID "+strconv.FormatInt(data.ProjectId.ValueInt64(), 10)+": "+err.Error(),
+		)
+		return
+	}
+
+	data.ApiKey = types.StringValue(project.ApiKey)
+	data.Token = types.StringValue(project.Token)
+	data.Secret = types.StringValue(project.Secret)
+
+	diags = resp.Result.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
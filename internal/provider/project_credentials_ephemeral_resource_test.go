@@ -0,0 +1,51 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"terraform-provider-mixpanel/internal/mixpanel"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func TestAccProjectCredentialsEphemeralResource(t *testing.T) {
+	server := testAccServer(t)
+	project := server.AddProject(mixpanel.Project{Name: "test"})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"mixpanel": testAccProtoV6ProviderFactories["mixpanel"],
+			// echo copies the ephemeral credentials into its state, only
+			// for the test to check them
+			"echo": echoprovider.NewProviderServer(),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + fmt.Sprintf(`
+ephemeral "mixpanel_project_credentials" "test" {
+  project_id = %d
+}
+
+provider "echo" {
+  data = ephemeral.mixpanel_project_credentials.test
+}
+
+resource "echo" "test" {}
+`, project.Id),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("echo.test", "data.api_key", project.ApiKey),
+					resource.TestCheckResourceAttr("echo.test", "data.token", project.Token),
+					resource.TestCheckResourceAttr("echo.test", "data.secret", project.Secret),
+				),
+			},
+		},
+	})
+}
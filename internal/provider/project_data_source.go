@@ -3,10 +3,13 @@ package provider
 import (
 	"context"
 	"fmt"
+	"strings"
 	"terraform-provider-mixpanel/internal/mixpanel"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
@@ -14,8 +17,9 @@ import (
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ datasource.DataSource              = &ProjectDataSource{}
-	_ datasource.DataSourceWithConfigure = &ProjectDataSource{}
+	_ datasource.DataSource                     = &ProjectDataSource{}
+	_ datasource.DataSourceWithConfigure        = &ProjectDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &ProjectDataSource{}
 )
 
 // NewprojectDataSource is a helper function to simplify the provider implementation.
@@ -28,7 +32,18 @@ type ProjectDataSource struct {
 	client *mixpanel.Client
 }
 
-type ProjectDataSourceModel = ProjectModel
+// ProjectDataSourceModel is ProjectModel with the organization used to
+// look a project up by name.
+type ProjectDataSourceModel struct {
+	Id             types.Int64           `tfsdk:"id"`
+	Name           basetypes.StringValue `tfsdk:"name"`
+	OrganizationId types.Int64           `tfsdk:"organization_id"`
+	Domain         basetypes.StringValue `tfsdk:"domain"`
+	Timezone       basetypes.StringValue `tfsdk:"timezone"`
+	ApiKey         basetypes.StringValue `tfsdk:"api_key"`
+	Token          basetypes.StringValue `tfsdk:"token"`
+	Secret         basetypes.StringValue `tfsdk:"secret"`
+}
 
 // Metadata returns the data source type name.
 func (d *ProjectDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -38,11 +53,25 @@ func (d *ProjectDataSource) Metadata(_ context.Context, req datasource.MetadataR
 // Schema defines the schema for the data source.
 func (d *ProjectDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		MarkdownDescription: "Reads a Mixpanel project, looked up by `id` or by `name`. " +
+			"The `api_key`, `token` and `secret` of the project are stored in the Terraform state, " +
+			"use the `mixpanel_project_credentials` ephemeral resource to keep them out of it.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.Int64Attribute{
-				Required: true,
+				MarkdownDescription: "ID of the project. Either `id` or `name` must be set.",
+				Optional:            true,
+				Computed:            true,
 			},
 			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the project. Either `id` or `name` must be set, " +
+					"reading fails unless exactly one project visible to the service account has this name.",
+				Optional: true,
+				Computed: true,
+			},
+			"organization_id": schema.Int64Attribute{
+				MarkdownDescription: "Only look for the project `name` in this organization, " +
+					"to tell apart projects with the same name in different organizations. Null when the project is read by `id`.",
+				Optional: true,
 				Computed: true,
 			},
 			"domain": schema.StringAttribute{
@@ -77,15 +106,43 @@ type ProjectModel struct {
 	Secret   basetypes.StringValue `tfsdk:"secret"`
 }
 
+// ConfigValidators returns the validators of the data source configuration.
+func (d *ProjectDataSource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("id"),
+			path.MatchRoot("name"),
+		),
+		datasourcevalidator.Conflicting(
+			path.MatchRoot("id"),
+			path.MatchRoot("organization_id"),
+		),
+	}
+}
+
 // Read refreshes the Terraform state with the latest data.
 func (d *ProjectDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	var id int64
+	var config ProjectDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	idPath := path.Root("id")
-	// Retrieve the id from the terraform data source
-	req.Config.GetAttribute(ctx, idPath, &id)
+	id := config.Id.ValueInt64()
+	organizationId := types.Int64Null()
 
-	project, err := d.client.GetProject(id)
+	if config.Id.IsNull() {
+		summary, diags := d.findProjectByName(ctx, config.Name.ValueString(), config.OrganizationId)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		id = summary.Id
+		organizationId = types.Int64Value(summary.OrganizationId)
+	}
+
+	project, err := d.client.GetProject(ctx, id)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read Mixpanel Project",
@@ -94,7 +151,17 @@ func (d *ProjectDataSource) Read(ctx context.Context, req datasource.ReadRequest
 		return
 	}
 
-	projectState := ProjectToProjectModel(project)
+	model := ProjectToProjectModel(project)
+	projectState := ProjectDataSourceModel{
+		Id:             model.Id,
+		Name:           model.Name,
+		OrganizationId: organizationId,
+		Domain:         model.Domain,
+		Timezone:       model.Timezone,
+		ApiKey:         model.ApiKey,
+		Token:          model.Token,
+		Secret:         model.Secret,
+	}
 
 	// Set state
 	diags := resp.State.Set(ctx, &projectState)
@@ -104,6 +171,61 @@ func (d *ProjectDataSource) Read(ctx context.Context, req datasource.ReadRequest
 	}
 }
 
+// findProjectByName returns the single project visible to the service account
+// with the given name, in the given organization when it is not null.
+func (d *ProjectDataSource) findProjectByName(ctx context.Context, name string, organizationId types.Int64) (*mixpanel.ProjectSummary, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	projects, err := d.client.GetProjects(ctx)
+	if err != nil {
+		diags.AddError(
+			"Unable to List Mixpanel Projects",
+			err.Error(),
+		)
+		return nil, diags
+	}
+
+	var matches []mixpanel.ProjectSummary
+	for _, project := range projects {
+		if project.Name != name {
+			continue
+		}
+		if !organizationId.IsNull() && project.OrganizationId != organizationId.ValueInt64() {
+			continue
+		}
+		matches = append(matches, project)
+	}
+
+	scope := "visible to the service account"
+	if !organizationId.IsNull() {
+		scope = fmt.Sprintf("in organization %d", organizationId.ValueInt64())
+	}
+
+	switch len(matches) {
+	case 0:
+		diags.AddAttributeError(
+			path.Root("name"),
+			"Mixpanel Project Not Found",
+			fmt.Sprintf("No project named %q %s.", name, scope),
+		)
+		return nil, diags
+	case 1:
+		return &matches[0], diags
+	default:
+		found := make([]string, 0, len(matches))
+		for _, project := range matches {
+			found = append(found, fmt.Sprintf("%d (organization %d)", project.Id, project.OrganizationId))
+		}
+		diags.AddAttributeError(
+			path.Root("name"),
+			"Multiple Mixpanel Projects Found",
+			fmt.Sprintf("%d projects named %q %s: %s. Set organization_id or look the project up by id instead.",
+				len(matches), name, scope, strings.Join(found, ", ")),
+		)
+		return nil, diags
+	}
+}
+
 // Configure adds the provider configured client to the data source.
 func (d *ProjectDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
 	// Add a nil check when handling ProviderData because Terraform
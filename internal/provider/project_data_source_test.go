@@ -0,0 +1,110 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"terraform-provider-mixpanel/internal/mixpanel"
+	"terraform-provider-mixpanel/internal/mixpanel/mixpaneltest"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccProjectDataSource(t *testing.T) {
+	server := testAccServer(t)
+	project := server.AddProject(mixpanel.Project{
+		Name:     "existing",
+		Domain:   "in.mixpanel.com",
+		Timezone: "Asia/Kolkata",
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + fmt.Sprintf(`
+data "mixpanel_project" "test" {
+  id = %d
+}
+`, project.Id),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mixpanel_project.test", "id", strconv.FormatInt(project.Id, 10)),
+					resource.TestCheckResourceAttr("data.mixpanel_project.test", "name", "existing"),
+					resource.TestCheckResourceAttr("data.mixpanel_project.test", "domain", "IN"),
+					resource.TestCheckResourceAttr("data.mixpanel_project.test", "timezone", "Asia/Kolkata"),
+					resource.TestCheckResourceAttr("data.mixpanel_project.test", "token", project.Token),
+				),
+			},
+			{
+				Config: testAccProviderConfig(server) + `
+data "mixpanel_project" "test" {
+  id = 1
+}
+`,
+				ExpectError: regexp.MustCompile(`not found`),
+			},
+		},
+	})
+}
+
+func TestAccProjectDataSource_name(t *testing.T) {
+	server := testAccServer(t)
+	server.AddOrganization(mixpanel.Organization{Id: 2, Name: "Other Organization"})
+	server.AddProject(mixpanel.Project{Name: "web"})
+	other := server.AddOrganizationProject(2, mixpanel.Project{Name: "web", Domain: "eu.mixpanel.com"})
+	unique := server.AddProject(mixpanel.Project{Name: "mobile"})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + `
+data "mixpanel_project" "mobile" {
+  name = "mobile"
+}
+
+data "mixpanel_project" "web" {
+  name            = "web"
+  organization_id = 2
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mixpanel_project.mobile", "id", strconv.FormatInt(unique.Id, 10)),
+					resource.TestCheckResourceAttr("data.mixpanel_project.mobile", "organization_id", strconv.FormatInt(mixpaneltest.DefaultOrganization.Id, 10)),
+					resource.TestCheckResourceAttr("data.mixpanel_project.web", "id", strconv.FormatInt(other.Id, 10)),
+					resource.TestCheckResourceAttr("data.mixpanel_project.web", "domain", "EU"),
+					resource.TestCheckResourceAttr("data.mixpanel_project.web", "token", other.Token),
+				),
+			},
+			{
+				Config: testAccProviderConfig(server) + `
+data "mixpanel_project" "web" {
+  name = "web"
+}
+`,
+				ExpectError: regexp.MustCompile(`Multiple Mixpanel Projects Found`),
+			},
+			{
+				Config: testAccProviderConfig(server) + `
+data "mixpanel_project" "missing" {
+  name = "missing"
+}
+`,
+				ExpectError: regexp.MustCompile(`Mixpanel Project Not Found`),
+			},
+			{
+				Config: testAccProviderConfig(server) + `
+data "mixpanel_project" "both" {
+  id   = 1
+  name = "web"
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+		},
+	})
+}
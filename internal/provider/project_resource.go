@@ -6,12 +6,16 @@ import (
 	"strconv"
 	"terraform-provider-mixpanel/internal/mixpanel"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 )
@@ -21,6 +25,7 @@ var (
 	_ resource.Resource                = &projectResource{}
 	_ resource.ResourceWithConfigure   = &projectResource{}
 	_ resource.ResourceWithImportState = &projectResource{}
+	_ resource.ResourceWithModifyPlan  = &projectResource{}
 )
 
 // NewProjectResource is a helper function to simplify the provider implementation.
@@ -61,7 +66,7 @@ func (r *projectResource) Metadata(_ context.Context, req resource.MetadataReque
 }
 
 // Schema defines the schema for the resource.
-func (r *projectResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *projectResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"id": schema.Int64Attribute{
@@ -74,7 +79,12 @@ func (r *projectResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				Required: true,
 			},
 			"domain": schema.StringAttribute{
+				MarkdownDescription: "Data residency of the project, one of `US`, `EU` or `IN`. Changing it creates a new, empty project " +
+					"and leaves the current one in Mixpanel, see `allow_domain_replacement`.",
 				Required: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(mixpanel.RegionUS, mixpanel.RegionEU, mixpanel.RegionIN),
+				},
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
@@ -94,22 +104,59 @@ func (r *projectResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				Computed:  true,
 				Sensitive: true,
 			},
+			"store_secrets": schema.BoolAttribute{
+				MarkdownDescription: "Whether `api_key` and `secret` are stored in the Terraform state. Default is `true`. " +
+					"When `false` both are null, use the `mixpanel_project_credentials` ephemeral resource to get them at apply time.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+			},
+			"allow_domain_replacement": schema.BoolAttribute{
+				MarkdownDescription: "Whether a change of `domain` may replace the project. Default is `false`, the plan fails instead. " +
+					"Mixpanel cannot move data between regions and the provider cannot delete projects, " +
+					"so the replaced project is left in Mixpanel with its data.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+			}),
 		},
 	}
 }
 
 // Read refreshes the Terraform state with the latest data.
 func (r *projectResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	var state ProjectModel
+	var state ProjectResourceModel
 	diags := req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	// Get refreshed project value from Mixpanel
-	project, err := r.client.GetProject(state.Id.ValueInt64())
+	project, err := r.client.GetProject(ctx, state.Id.ValueInt64())
 	if err != nil {
+		if mixpanel.IsNotFound(err) {
+			// The project was deleted outside of Terraform, plan to create it again
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
 		resp.Diagnostics.AddError(
 			"Error Reading Mixpanel Project",
 			"Could not read Mixpanel project ID "+strconv.FormatInt(state.Id.ValueInt64(), 10)+": "+err.Error(),
@@ -117,8 +164,16 @@ func (r *projectResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
+	// Projects imported have no settings yet
+	if state.StoreSecrets.IsNull() {
+		state.StoreSecrets = types.BoolValue(true)
+	}
+	if state.AllowDomainReplacement.IsNull() {
+		state.AllowDomainReplacement = types.BoolValue(false)
+	}
+
 	// Update the state with the refreshed data
-	state = ProjectToProjectModel(project)
+	state = ProjectToProjectResourceModel(project, state)
 
 	// Set refreshed state
 	diags = resp.State.Set(ctx, &state)
@@ -131,8 +186,8 @@ func (r *projectResource) Read(ctx context.Context, req resource.ReadRequest, re
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *projectResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var plan ProjectModel
-	var state ProjectModel
+	var plan ProjectResourceModel
+	var state ProjectResourceModel
 
 	// Get the planned new state
 	diags := req.Plan.Get(ctx, &plan)
@@ -141,6 +196,15 @@ func (r *projectResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	// Get the current state
 	diags = req.State.Get(ctx, &state)
 	if diags.HasError() {
@@ -149,7 +213,7 @@ func (r *projectResource) Update(ctx context.Context, req resource.UpdateRequest
 	}
 
 	if plan.Name != state.Name {
-		err := r.client.UpdateProjectName(state.Id.ValueInt64(), plan.Name.ValueString())
+		err := r.client.UpdateProjectName(ctx, state.Id.ValueInt64(), plan.Name.ValueString())
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Unable to update Mixpanel Project Name",
@@ -160,7 +224,7 @@ func (r *projectResource) Update(ctx context.Context, req resource.UpdateRequest
 	}
 
 	if plan.Timezone != state.Timezone {
-		err := r.client.UpdateProjectTimezone(state.Id.ValueInt64(), plan.Timezone.ValueString())
+		err := r.client.UpdateProjectTimezone(ctx, state.Id.ValueInt64(), plan.Timezone.ValueString())
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Unable to update Mixpanel Project Timezone",
@@ -171,7 +235,7 @@ func (r *projectResource) Update(ctx context.Context, req resource.UpdateRequest
 	}
 
 	// Get refreshed project value from Mixpanel
-	project, err := r.client.GetProject(state.Id.ValueInt64())
+	project, err := r.client.GetProject(ctx, state.Id.ValueInt64())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Mixpanel Project",
@@ -180,34 +244,120 @@ func (r *projectResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
-	diags = resp.State.Set(ctx, ProjectToProjectModel(project))
+	diags = resp.State.Set(ctx, ProjectToProjectResourceModel(project, plan))
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 }
 
-// Delete deletes the resource and removes the Terraform state on success.
+// Delete removes the resource from the Terraform state, the project itself is
+// left in Mixpanel.
 func (r *projectResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
-	// Not Implemented, Service Account does not have permission to delete projects and we don't support any other authentication method yet
+	// Not Implemented, Service Account does not have permission to delete projects and we don't support any other authentication method yet,
+	// warn that the project is left behind instead
+	var state ProjectResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.AddWarning(
+		"Mixpanel Project Not Deleted",
+		fmt.Sprintf("Mixpanel project ID %d (%s, domain %s) was removed from the Terraform state but still exists in Mixpanel, with its data. "+
+			"Delete it in the Mixpanel project settings once it is no longer needed.",
+			state.Id.ValueInt64(), state.Name.ValueString(), state.Domain.ValueString()),
+	)
+}
+
+// ModifyPlan stops a change of domain from replacing the project unless
+// allow_domain_replacement is set, and reports the data left behind when it is.
+func (r *projectResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing is replaced on create and destroy
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan ProjectResourceModel
+	var state ProjectResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.Domain.IsUnknown() || plan.Domain.Equal(state.Domain) {
+		return
+	}
+
+	id := state.Id.ValueInt64()
+
+	if !plan.AllowDomainReplacement.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("domain"),
+			"Mixpanel Project Domain Change Not Allowed",
+			fmt.Sprintf("Changing the domain from %s to %s replaces Mixpanel project ID %d with a new, empty project. "+
+				"Mixpanel cannot move data between regions and the provider cannot delete projects, so project ID %d would be left in Mixpanel with its data. "+
+				"Set allow_domain_replacement = true to replace the project anyway.",
+				state.Domain.ValueString(), plan.Domain.ValueString(), id, id),
+		)
+		return
+	}
+
+	// Report the data left behind, the check is best effort
+	volume := int64(-1)
+	var err error
+	if r.client != nil {
+		volume, err = r.client.GetEventVolume(ctx, id, state.Domain.ValueString(), mixpanel.EventVolumeDays)
+	}
+	if err != nil {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("domain"),
+			"Unable to Check Mixpanel Project Data",
+			fmt.Sprintf("Could not count the events of Mixpanel project ID %d: %s", id, err.Error()),
+		)
+		volume = -1
+	}
+
+	detail := fmt.Sprintf("Mixpanel project ID %d (%s, domain %s) will be replaced by a new project in %s and left in Mixpanel, it will not be deleted. ",
+		id, state.Name.ValueString(), state.Domain.ValueString(), plan.Domain.ValueString())
+	switch {
+	case volume > 0:
+		detail += fmt.Sprintf("It ingested %d events over the last %d days, which stay in project ID %d. ", volume, mixpanel.EventVolumeDays, id)
+	case volume == 0:
+		detail += fmt.Sprintf("It ingested no events over the last %d days. ", mixpanel.EventVolumeDays)
+	}
+	detail += "Send data with the token of the new project, and delete the old one in Mixpanel once it is no longer needed."
+
+	resp.Diagnostics.AddAttributeWarning(path.Root("domain"), "Mixpanel Project Will Be Orphaned", detail)
 }
 
 // Create creates the resource and sets the initial Terraform state.
 func (r *projectResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var plan ProjectModel
+	var plan ProjectResourceModel
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	data := mixpanel.Project{
 		Name:     plan.Name.ValueString(),
 		Domain:   plan.Domain.ValueString(),
 		Timezone: plan.Timezone.ValueString(),
 	}
 
-	newProject, err := r.client.CreateProject(&data)
+	newProject, err := r.client.CreateProject(ctx, &data)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to create Mixpanel Project",
@@ -216,7 +366,7 @@ func (r *projectResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
-	project, err := r.client.GetProject(newProject.Id)
+	project, err := r.client.GetProject(ctx, newProject.Id)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read Mixpanel Project",
@@ -226,7 +376,7 @@ func (r *projectResource) Create(ctx context.Context, req resource.CreateRequest
 	}
 
 	// Set state to fully populated data
-	diags = resp.State.Set(ctx, ProjectToProjectModel(project))
+	diags = resp.State.Set(ctx, ProjectToProjectResourceModel(project, plan))
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -248,6 +398,47 @@ func (r *projectResource) ImportState(ctx context.Context, req resource.ImportSt
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
 }
 
+// ProjectResourceModel is the resource counterpart of ProjectModel, with the
+// operation timeouts only resources have.
+type ProjectResourceModel struct {
+	Id                     types.Int64           `tfsdk:"id"`
+	Name                   basetypes.StringValue `tfsdk:"name"`
+	Domain                 basetypes.StringValue `tfsdk:"domain"`
+	Timezone               basetypes.StringValue `tfsdk:"timezone"`
+	ApiKey                 basetypes.StringValue `tfsdk:"api_key"`
+	Token                  basetypes.StringValue `tfsdk:"token"`
+	Secret                 basetypes.StringValue `tfsdk:"secret"`
+	StoreSecrets           types.Bool            `tfsdk:"store_secrets"`
+	AllowDomainReplacement types.Bool            `tfsdk:"allow_domain_replacement"`
+	Timeouts               timeouts.Value        `tfsdk:"timeouts"`
+}
+
+// ProjectToProjectResourceModel maps project to the resource state, keeping
+// the settings of config that Mixpanel does not store. api_key and secret
+// are null unless store_secrets is true.
+func ProjectToProjectResourceModel(project *mixpanel.Project, config ProjectResourceModel) ProjectResourceModel {
+	model := ProjectToProjectModel(project)
+	resourceModel := ProjectResourceModel{
+		Id:                     model.Id,
+		Name:                   model.Name,
+		Domain:                 model.Domain,
+		Timezone:               model.Timezone,
+		ApiKey:                 model.ApiKey,
+		Token:                  model.Token,
+		Secret:                 model.Secret,
+		StoreSecrets:           config.StoreSecrets,
+		AllowDomainReplacement: config.AllowDomainReplacement,
+		Timeouts:               config.Timeouts,
+	}
+
+	if !config.StoreSecrets.ValueBool() {
+		resourceModel.ApiKey = types.StringNull()
+		resourceModel.Secret = types.StringNull()
+	}
+
+	return resourceModel
+}
+
 func ProjectToProjectModel(project *mixpanel.Project) ProjectModel {
 	return ProjectModel{
 		Id:       types.Int64Value(project.Id),
@@ -0,0 +1,213 @@
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"terraform-provider-mixpanel/internal/mixpanel/mixpaneltest"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func testAccProjectResourceConfig(server *mixpaneltest.Server, name, timezone string) string {
+	return testAccProviderConfig(server) + fmt.Sprintf(`
+resource "mixpanel_project" "test" {
+  name     = %q
+  domain   = "EU"
+  timezone = %q
+}
+`, name, timezone)
+}
+
+// testAccProjectId stores the ID of the project in state into id.
+func testAccProjectId(id *int64) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources["mixpanel_project.test"]
+		if !ok {
+			return fmt.Errorf("mixpanel_project.test not found in state")
+		}
+
+		var err error
+		*id, err = strconv.ParseInt(rs.Primary.ID, 10, 64)
+		return err
+	}
+}
+
+func TestAccProjectResource(t *testing.T) {
+	server := testAccServer(t)
+	var id int64
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccProjectResourceConfig(server, "test", "Europe/Paris"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccProjectId(&id),
+					resource.TestCheckResourceAttr("mixpanel_project.test", "name", "test"),
+					resource.TestCheckResourceAttr("mixpanel_project.test", "domain", "EU"),
+					resource.TestCheckResourceAttr("mixpanel_project.test", "timezone", "Europe/Paris"),
+					resource.TestCheckResourceAttrSet("mixpanel_project.test", "token"),
+					resource.TestCheckResourceAttrSet("mixpanel_project.test", "secret"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "mixpanel_project.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"timeouts"},
+			},
+			// Update and Read testing
+			{
+				Config: testAccProjectResourceConfig(server, "renamed", "UTC"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mixpanel_project.test", "name", "renamed"),
+					resource.TestCheckResourceAttr("mixpanel_project.test", "timezone", "UTC"),
+					func(*terraform.State) error {
+						project, ok := server.Project(id)
+						if !ok || project.Name != "renamed" || project.Timezone != "UTC" {
+							return fmt.Errorf("project not updated in Mixpanel: %+v", project)
+						}
+						return nil
+					},
+				),
+			},
+			// A project deleted outside of Terraform is created again
+			{
+				PreConfig:          func() { server.DeleteProject(id) },
+				Config:             testAccProjectResourceConfig(server, "renamed", "UTC"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccProjectResource_retry(t *testing.T) {
+	server := testAccServer(t)
+
+	// The first attempts are rate limited or fail, the client retries them
+	server.Fail(mixpaneltest.Failure{Method: http.MethodPost, Path: "/api/app/organizations/", StatusCode: http.StatusTooManyRequests, Times: 1})
+	server.Fail(mixpaneltest.Failure{Method: http.MethodGet, Path: "/settings/project/", StatusCode: http.StatusBadGateway, Times: 2})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProjectResourceConfig(server, "test", "Europe/Paris"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mixpanel_project.test", "name", "test"),
+					func(*terraform.State) error {
+						if count := len(server.ProjectIds()); count != 1 {
+							return fmt.Errorf("expected a single project in Mixpanel, got %d", count)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccProjectResource_storeSecrets(t *testing.T) {
+	server := testAccServer(t)
+
+	config := func(storeSecrets bool) string {
+		return testAccProviderConfig(server) + fmt.Sprintf(`
+resource "mixpanel_project" "test" {
+  name          = "test"
+  domain        = "EU"
+  timezone      = "Europe/Paris"
+  store_secrets = %t
+}
+`, storeSecrets)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("mixpanel_project.test", "token"),
+					resource.TestCheckNoResourceAttr("mixpanel_project.test", "api_key"),
+					resource.TestCheckNoResourceAttr("mixpanel_project.test", "secret"),
+				),
+			},
+			{
+				Config: config(true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("mixpanel_project.test", "api_key"),
+					resource.TestCheckResourceAttrSet("mixpanel_project.test", "secret"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccProjectResource_domainChange(t *testing.T) {
+	server := testAccServer(t)
+	var id, replacementId int64
+
+	config := func(domain string, allowDomainReplacement bool) string {
+		return testAccProviderConfig(server) + fmt.Sprintf(`
+resource "mixpanel_project" "test" {
+  name                     = "test"
+  domain                   = %q
+  timezone                 = "UTC"
+  allow_domain_replacement = %t
+}
+`, domain, allowDomainReplacement)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("US", false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccProjectId(&id),
+					func(*terraform.State) error {
+						server.AddEvents(id, "Sign Up", 3)
+						return nil
+					},
+				),
+			},
+			// The project is not replaced without allow_domain_replacement
+			{
+				Config:      config("EU", false),
+				ExpectError: regexp.MustCompile(`Mixpanel Project Domain Change Not Allowed`),
+			},
+			// The project is replaced, the previous one is left in Mixpanel
+			{
+				Config: config("EU", true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mixpanel_project.test", "domain", "EU"),
+					testAccProjectId(&replacementId),
+					func(*terraform.State) error {
+						if replacementId == id {
+							return fmt.Errorf("project %d was not replaced", id)
+						}
+						if _, ok := server.Project(id); !ok {
+							return fmt.Errorf("project %d was deleted", id)
+						}
+						if server.RequestCount(http.MethodGet, "/api/query/events") == 0 {
+							return fmt.Errorf("event volume of project %d was not checked", id)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,295 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"terraform-provider-mixpanel/internal/mixpanel"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource               = &projectSecretRotationResource{}
+	_ resource.ResourceWithConfigure  = &projectSecretRotationResource{}
+	_ resource.ResourceWithModifyPlan = &projectSecretRotationResource{}
+)
+
+// NewProjectSecretRotationResource is a helper function to simplify the provider implementation.
+func NewProjectSecretRotationResource() resource.Resource {
+	return &projectSecretRotationResource{}
+}
+
+// projectSecretRotationResource is the resource implementation.
+type projectSecretRotationResource struct {
+	client *mixpanel.Client
+}
+
+type ProjectSecretRotationModel struct {
+	Id          types.Int64    `tfsdk:"id"`
+	ProjectId   types.Int64    `tfsdk:"project_id"`
+	Keepers     types.Map      `tfsdk:"keepers"`
+	Secret      types.String   `tfsdk:"secret"`
+	StoreSecret types.Bool     `tfsdk:"store_secret"`
+	RotatedAt   types.String   `tfsdk:"rotated_at"`
+	Timeouts    timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *projectSecretRotationResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*mixpanel.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *mixpanel.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// Metadata returns the resource type name.
+func (r *projectSecretRotationResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_project_secret_rotation"
+}
+
+// Schema defines the schema for the resource.
+func (r *projectSecretRotationResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Rotates the API secret of a Mixpanel project. The secret is reset when the resource is created, " +
+			"and again whenever `keepers` change. Resources referencing `secret` are updated in the same apply, " +
+			"while the `secret` of `mixpanel_project` catches up on its next refresh. Destroying the resource leaves the secret as it is.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				MarkdownDescription: "Same as `project_id`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_id": schema.Int64Attribute{
+				MarkdownDescription: "ID of the project whose secret is rotated.",
+				Required:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"keepers": schema.MapAttribute{
+				MarkdownDescription: "Arbitrary values that trigger a new rotation when they change, e.g. a date or a ticket number.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"secret": schema.StringAttribute{
+				MarkdownDescription: "Current API secret of the project. Null when `store_secret` is `false`.",
+				Computed:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"store_secret": schema.BoolAttribute{
+				MarkdownDescription: "Whether `secret` is stored in the Terraform state. Default is `true`. " +
+					"When `false` it is null, use the `mixpanel_project_credentials` ephemeral resource to get the rotated secret at apply time. " +
+					"Changing it does not rotate the secret again.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+			},
+			"rotated_at": schema.StringAttribute{
+				MarkdownDescription: "Time of the last rotation, in RFC 3339 format.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+			}),
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *projectSecretRotationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state ProjectSecretRotationModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	project, err := r.client.GetProject(ctx, state.ProjectId.ValueInt64())
+	if err != nil {
+		if mixpanel.IsNotFound(err) {
+			// The project was deleted along with its secret
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error Reading Mixpanel Project",
+			"Could not read Mixpanel project ID "+strconv.FormatInt(state.ProjectId.ValueInt64(), 10)+": "+err.Error(),
+		)
+		return
+	}
+
+	// Resources created before store_secret have no setting yet
+	if state.StoreSecret.IsNull() {
+		state.StoreSecret = types.BoolValue(true)
+	}
+
+	// Reflect a secret reset outside of Terraform, so that dependent
+	// resources are updated
+	state.Secret = secretRotationSecret(state, project.Secret)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Create resets the secret of the project and sets the initial Terraform state.
+func (r *projectSecretRotationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan ProjectSecretRotationModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	secret, err := r.client.ResetProjectSecret(ctx, plan.ProjectId.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to reset Mixpanel Project Secret",
+			err.Error(),
+		)
+		return
+	}
+
+	plan.Id = plan.ProjectId
+	plan.Secret = secretRotationSecret(plan, secret)
+	plan.RotatedAt = types.StringValue(time.Now().UTC().Format(time.RFC3339))
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// ModifyPlan plans the secret again when store_secret changes, the rest of
+// the plan keeps the secret of the state.
+func (r *projectSecretRotationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Rotations plan an unknown secret on their own
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan ProjectSecretRotationModel
+	var state ProjectSecretRotationModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.StoreSecret.IsUnknown() || plan.StoreSecret.Equal(state.StoreSecret) {
+		return
+	}
+
+	secret := types.StringNull()
+	if plan.StoreSecret.ValueBool() {
+		secret = types.StringUnknown()
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("secret"), secret)...)
+}
+
+// Update stores the new timeouts and store_secret, any other change requires
+// a new rotation. The secret is read back when store_secret becomes true.
+func (r *projectSecretRotationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan ProjectSecretRotationModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.Secret.IsUnknown() {
+		updateTimeout, diags := plan.Timeouts.Update(ctx, defaultTimeout)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+		defer cancel()
+
+		project, err := r.client.GetProject(ctx, plan.ProjectId.ValueInt64())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading Mixpanel Project",
+				"Could not read Mixpanel project ID "+strconv.FormatInt(plan.ProjectId.ValueInt64(), 10)+": "+err.Error(),
+			)
+			return
+		}
+
+		plan.Secret = secretRotationSecret(plan, project.Secret)
+	}
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// secretRotationSecret returns the value of secret in model, null unless
+// store_secret is true.
+func secretRotationSecret(model ProjectSecretRotationModel, secret string) types.String {
+	if !model.StoreSecret.ValueBool() {
+		return types.StringNull()
+	}
+	return types.StringValue(secret)
+}
+
+// Delete removes the resource from the Terraform state.
+func (r *projectSecretRotationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// A secret cannot be restored, the current one is kept
+}
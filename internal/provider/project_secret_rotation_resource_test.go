@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"terraform-provider-mixpanel/internal/mixpanel"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func testAccProjectSecretRotationConfig(projectId int64, rotation string) string {
+	return fmt.Sprintf(`
+resource "mixpanel_project_secret_rotation" "test" {
+  project_id = %d
+  keepers = {
+    rotation = %q
+  }
+}
+`, projectId, rotation)
+}
+
+func TestAccProjectSecretRotationResource(t *testing.T) {
+	server := testAccServer(t)
+	project := server.AddProject(mixpanel.Project{Name: "test"})
+	secrets := map[string]bool{project.Secret: true}
+
+	// testAccCheckRotated checks that the secret in state is a new one, the
+	// one Mixpanel now has
+	testAccCheckRotated := func(s *terraform.State) error {
+		secret := s.RootModule().Resources["mixpanel_project_secret_rotation.test"].Primary.Attributes["secret"]
+		current, _ := server.Project(project.Id)
+		if secret != current.Secret {
+			return fmt.Errorf("secret in state is not the project secret")
+		}
+		if secrets[secret] {
+			return fmt.Errorf("secret was not rotated")
+		}
+		secrets[secret] = true
+		return nil
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + testAccProjectSecretRotationConfig(project.Id, "first"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("mixpanel_project_secret_rotation.test", "rotated_at"),
+					testAccCheckRotated,
+				),
+			},
+			// Changing the keepers rotates the secret again
+			{
+				Config: testAccProviderConfig(server) + testAccProjectSecretRotationConfig(project.Id, "second"),
+				Check:  testAccCheckRotated,
+			},
+		},
+	})
+}
+
+func TestAccProjectSecretRotationResource_storeSecret(t *testing.T) {
+	server := testAccServer(t)
+	project := server.AddProject(mixpanel.Project{Name: "test"})
+	var rotated string
+
+	config := func(storeSecret bool) string {
+		return testAccProviderConfig(server) + fmt.Sprintf(`
+resource "mixpanel_project_secret_rotation" "test" {
+  project_id   = %d
+  store_secret = %t
+}
+`, project.Id, storeSecret)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("mixpanel_project_secret_rotation.test", "secret"),
+					func(s *terraform.State) error {
+						current, _ := server.Project(project.Id)
+						if current.Secret == project.Secret {
+							return fmt.Errorf("secret was not rotated")
+						}
+						rotated = current.Secret
+						return nil
+					},
+				),
+			},
+			// Storing the secret reads it back without rotating it again
+			{
+				Config: config(true),
+				Check: func(s *terraform.State) error {
+					return resource.TestCheckResourceAttr("mixpanel_project_secret_rotation.test", "secret", rotated)(s)
+				},
+			},
+			{
+				Config: config(false),
+				Check:  resource.TestCheckNoResourceAttr("mixpanel_project_secret_rotation.test", "secret"),
+			},
+		},
+	})
+}
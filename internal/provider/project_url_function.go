@@ -0,0 +1,189 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"terraform-provider-mixpanel/internal/mixpanel"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ function.Function = &projectUrlFunction{}
+	_ function.Function = &parseProjectUrlFunction{}
+)
+
+// Regions in the order their app hosts are matched.
+var projectUrlRegions = []string{mixpanel.RegionUS, mixpanel.RegionEU, mixpanel.RegionIN}
+
+var projectUrlPathRegexp = regexp.MustCompile(`^/project/(\d+)(?:/(.*))?$`)
+
+// NewProjectUrlFunction is a helper function to simplify the provider implementation.
+func NewProjectUrlFunction() function.Function {
+	return &projectUrlFunction{}
+}
+
+// projectUrlFunction builds links to the Mixpanel app.
+type projectUrlFunction struct{}
+
+func (f *projectUrlFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "project_url"
+}
+
+func (f *projectUrlFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Build a link to a page of a Mixpanel project",
+		MarkdownDescription: "Builds the URL of a page of a Mixpanel project, such as a board or a report, " +
+			"on the app host of the project region.",
+		Parameters: []function.Parameter{
+			function.Int64Parameter{
+				Name:                "id",
+				MarkdownDescription: "ID of the project.",
+			},
+			function.StringParameter{
+				Name:                "domain",
+				MarkdownDescription: "Data residency of the project, one of `US`, `EU` or `IN`.",
+			},
+			function.StringParameter{
+				Name: "path",
+				MarkdownDescription: "Page of the project, relative to the project root, e.g. `view/123/app/boards#id=456`. " +
+					"An empty path links to the project home.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *projectUrlFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var id int64
+	var domain, path string
+
+	resp.Error = req.Arguments.Get(ctx, &id, &domain, &path)
+	if resp.Error != nil {
+		return
+	}
+
+	if id <= 0 {
+		resp.Error = function.NewArgumentFuncError(0, "id must be a positive project ID")
+		return
+	}
+
+	endpoints, ok := mixpanel.RegionEndpoints(domain)
+	if !ok {
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("domain must be one of US, EU or IN, got: %q", domain))
+		return
+	}
+
+	projectUrl := fmt.Sprintf("%s/project/%d", endpoints.App, id)
+	if path = strings.TrimPrefix(path, "/"); path != "" {
+		projectUrl += "/" + path
+	}
+
+	resp.Error = resp.Result.Set(ctx, projectUrl)
+}
+
+// NewParseProjectUrlFunction is a helper function to simplify the provider implementation.
+func NewParseProjectUrlFunction() function.Function {
+	return &parseProjectUrlFunction{}
+}
+
+// parseProjectUrlFunction is the inverse of projectUrlFunction.
+type parseProjectUrlFunction struct{}
+
+type ProjectUrlModel struct {
+	Id     types.Int64  `tfsdk:"id"`
+	Domain types.String `tfsdk:"domain"`
+	Path   types.String `tfsdk:"path"`
+}
+
+func (f *parseProjectUrlFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_project_url"
+}
+
+func (f *parseProjectUrlFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Decompose a link to a page of a Mixpanel project",
+		MarkdownDescription: "Decomposes the URL of a page of a Mixpanel project into an object with the `id` and `domain` " +
+			"of the project and the `path` of the page, as taken by `project_url`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "url",
+				MarkdownDescription: "URL of a page of a Mixpanel project.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: map[string]attr.Type{
+				"id":     types.Int64Type,
+				"domain": types.StringType,
+				"path":   types.StringType,
+			},
+		},
+	}
+}
+
+func (f *parseProjectUrlFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var rawUrl string
+
+	resp.Error = req.Arguments.Get(ctx, &rawUrl)
+	if resp.Error != nil {
+		return
+	}
+
+	result, err := parseProjectUrl(rawUrl)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, result)
+}
+
+func parseProjectUrl(rawUrl string) (*ProjectUrlModel, error) {
+	parsed, err := url.Parse(rawUrl)
+	if err != nil {
+		return nil, err
+	}
+
+	domain := ""
+	for _, region := range projectUrlRegions {
+		endpoints, _ := mixpanel.RegionEndpoints(region)
+		if strings.EqualFold(parsed.Scheme+"://"+parsed.Host, endpoints.App) {
+			domain = region
+		}
+	}
+	if domain == "" {
+		return nil, fmt.Errorf("not a Mixpanel app URL: %q", rawUrl)
+	}
+
+	match := projectUrlPathRegexp.FindStringSubmatch(parsed.EscapedPath())
+	if match == nil {
+		return nil, fmt.Errorf("not a Mixpanel project URL: %q", rawUrl)
+	}
+
+	id, err := strconv.ParseInt(match[1], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid project ID in %q: %w", rawUrl, err)
+	}
+
+	// Keep the query and fragment, they select the board or report
+	path := match[2]
+	if parsed.RawQuery != "" {
+		path += "?" + parsed.RawQuery
+	}
+	if parsed.Fragment != "" {
+		path += "#" + parsed.EscapedFragment()
+	}
+
+	return &ProjectUrlModel{
+		Id:     types.Int64Value(id),
+		Domain: types.StringValue(domain),
+		Path:   types.StringValue(path),
+	}, nil
+}
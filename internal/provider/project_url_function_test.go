@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestProjectUrlFunction(t *testing.T) {
+	tests := []struct {
+		id     int64
+		domain string
+		path   string
+		url    string
+	}{
+		{1234, "US", "", "https://mixpanel.com/project/1234"},
+		{1234, "EU", "view/56/app/boards#id=789", "https://eu.mixpanel.com/project/1234/view/56/app/boards#id=789"},
+		{1234, "IN", "/view/56/app/insights?x=1#report-id", "https://in.mixpanel.com/project/1234/view/56/app/insights?x=1#report-id"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.url, func(t *testing.T) {
+			ctx := context.Background()
+
+			resp := function.RunResponse{Result: function.NewResultData(types.StringUnknown())}
+			NewProjectUrlFunction().Run(ctx, function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{
+					types.Int64Value(test.id),
+					types.StringValue(test.domain),
+					types.StringValue(test.path),
+				}),
+			}, &resp)
+			if resp.Error != nil {
+				t.Fatal(resp.Error)
+			}
+			result, ok := resp.Result.Value().(types.String)
+			if !ok {
+				t.Fatalf("project_url: unexpected result type %T", resp.Result.Value())
+			}
+			if got := result.ValueString(); got != test.url {
+				t.Errorf("project_url: got %q, want %q", got, test.url)
+			}
+
+			parsed, err := parseProjectUrl(test.url)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if parsed.Id.ValueInt64() != test.id || parsed.Domain.ValueString() != test.domain {
+				t.Errorf("parse_project_url: unexpected result %+v", parsed)
+			}
+		})
+	}
+}
+
+func TestParseProjectUrlInvalid(t *testing.T) {
+	for _, url := range []string{
+		"https://example.com/project/1234",
+		"https://mixpanel.com/report/1234",
+		"https://mixpanel.com/project/abc",
+		"mixpanel.com/project/1234",
+	} {
+		if _, err := parseProjectUrl(url); err == nil {
+			t.Errorf("expected %q to be rejected", url)
+		}
+	}
+}
+
+func TestProjectUrlFunctionInvalidDomain(t *testing.T) {
+	resp := function.RunResponse{Result: function.NewResultData(types.StringUnknown())}
+	NewProjectUrlFunction().Run(context.Background(), function.RunRequest{
+		Arguments: function.NewArgumentsData([]attr.Value{
+			types.Int64Value(1234),
+			types.StringValue("eu"),
+			types.StringValue(""),
+		}),
+	}, &resp)
+
+	if resp.Error == nil || resp.Error.FunctionArgument == nil || *resp.Error.FunctionArgument != 1 {
+		t.Errorf("expected an error on the domain argument, got %v", resp.Error)
+	}
+}
+
+func TestParseProjectUrlFunction(t *testing.T) {
+	ctx := context.Background()
+	attributeTypes := map[string]attr.Type{
+		"id":     types.Int64Type,
+		"domain": types.StringType,
+		"path":   types.StringType,
+	}
+
+	resp := function.RunResponse{Result: function.NewResultData(types.ObjectUnknown(attributeTypes))}
+	NewParseProjectUrlFunction().Run(ctx, function.RunRequest{
+		Arguments: function.NewArgumentsData([]attr.Value{
+			types.StringValue("https://eu.mixpanel.com/project/1234/view/56/app/boards#id=789"),
+		}),
+	}, &resp)
+	if resp.Error != nil {
+		t.Fatal(resp.Error)
+	}
+
+	want := types.ObjectValueMust(attributeTypes, map[string]attr.Value{
+		"id":     types.Int64Value(1234),
+		"domain": types.StringValue("EU"),
+		"path":   types.StringValue("view/56/app/boards#id=789"),
+	})
+	if got := resp.Result.Value(); !got.Equal(want) {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,177 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"terraform-provider-mixpanel/internal/mixpanel"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &ProjectsDataSource{}
+	_ datasource.DataSourceWithConfigure = &ProjectsDataSource{}
+)
+
+// NewProjectsDataSource is a helper function to simplify the provider implementation.
+func NewProjectsDataSource() datasource.DataSource {
+	return &ProjectsDataSource{}
+}
+
+// ProjectsDataSource is the data source implementation.
+type ProjectsDataSource struct {
+	client *mixpanel.Client
+}
+
+type ProjectsDataSourceModel struct {
+	NameRegex types.String          `tfsdk:"name_regex"`
+	Domain    types.String          `tfsdk:"domain"`
+	Timezone  types.String          `tfsdk:"timezone"`
+	Projects  []ProjectSummaryModel `tfsdk:"projects"`
+}
+
+type ProjectSummaryModel struct {
+	Id             types.Int64  `tfsdk:"id"`
+	Name           types.String `tfsdk:"name"`
+	OrganizationId types.Int64  `tfsdk:"organization_id"`
+	Domain         types.String `tfsdk:"domain"`
+	Timezone       types.String `tfsdk:"timezone"`
+}
+
+// Metadata returns the data source type name.
+func (d *ProjectsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_projects"
+}
+
+// Schema defines the schema for the data source.
+func (d *ProjectsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the Mixpanel projects the service account has access to, across organizations.",
+		Attributes: map[string]schema.Attribute{
+			"name_regex": schema.StringAttribute{
+				MarkdownDescription: "Only list the projects whose name matches this regular expression.",
+				Optional:            true,
+			},
+			"domain": schema.StringAttribute{
+				MarkdownDescription: "Only list the projects of this data residency, one of `US`, `EU` or `IN`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(mixpanel.RegionUS, mixpanel.RegionEU, mixpanel.RegionIN),
+				},
+			},
+			"timezone": schema.StringAttribute{
+				MarkdownDescription: "Only list the projects in this timezone.",
+				Optional:            true,
+			},
+			"projects": schema.ListNestedAttribute{
+				MarkdownDescription: "Matching projects, ordered by ID.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Computed: true,
+						},
+						"name": schema.StringAttribute{
+							Computed: true,
+						},
+						"organization_id": schema.Int64Attribute{
+							MarkdownDescription: "ID of the organization the project belongs to.",
+							Computed:            true,
+						},
+						"domain": schema.StringAttribute{
+							Computed: true,
+						},
+						"timezone": schema.StringAttribute{
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *ProjectsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state ProjectsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var nameRegex *regexp.Regexp
+	if !state.NameRegex.IsNull() {
+		var err error
+		nameRegex, err = regexp.Compile(state.NameRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name_regex"),
+				"Invalid Name Regex",
+				err.Error(),
+			)
+			return
+		}
+	}
+
+	projects, err := d.client.GetProjects(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to List Mixpanel Projects",
+			err.Error(),
+		)
+		return
+	}
+
+	state.Projects = []ProjectSummaryModel{}
+	for _, project := range projects {
+		if nameRegex != nil && !nameRegex.MatchString(project.Name) {
+			continue
+		}
+		if !state.Domain.IsNull() && project.Domain != state.Domain.ValueString() {
+			continue
+		}
+		if !state.Timezone.IsNull() && project.Timezone != state.Timezone.ValueString() {
+			continue
+		}
+
+		state.Projects = append(state.Projects, ProjectSummaryToModel(project))
+	}
+
+	// Set state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *ProjectsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*mixpanel.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *mixpanel.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
+
+func ProjectSummaryToModel(project mixpanel.ProjectSummary) ProjectSummaryModel {
+	return ProjectSummaryModel{
+		Id:             types.Int64Value(project.Id),
+		Name:           types.StringValue(project.Name),
+		OrganizationId: types.Int64Value(project.OrganizationId),
+		Domain:         types.StringValue(project.Domain),
+		Timezone:       types.StringValue(project.Timezone),
+	}
+}
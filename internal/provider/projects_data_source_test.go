@@ -0,0 +1,44 @@
+package provider
+
+import (
+	"strconv"
+	"testing"
+
+	"terraform-provider-mixpanel/internal/mixpanel"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccProjectsDataSource(t *testing.T) {
+	server := testAccServer(t)
+	server.AddOrganization(mixpanel.Organization{Id: 2, Name: "Other Organization"})
+	server.AddProject(mixpanel.Project{Name: "web-prod", Domain: "eu.mixpanel.com", Timezone: "Europe/Paris"})
+	staging := server.AddOrganizationProject(2, mixpanel.Project{Name: "web-staging", Domain: "eu.mixpanel.com", Timezone: "UTC"})
+	server.AddProject(mixpanel.Project{Name: "mobile-prod", Domain: "mixpanel.com", Timezone: "UTC"})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + `
+data "mixpanel_projects" "all" {}
+
+data "mixpanel_projects" "web_utc" {
+  name_regex = "^web-"
+  domain     = "EU"
+  timezone   = "UTC"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.mixpanel_projects.all", "projects.#", "3"),
+					resource.TestCheckResourceAttr("data.mixpanel_projects.web_utc", "projects.#", "1"),
+					resource.TestCheckResourceAttr("data.mixpanel_projects.web_utc", "projects.0.id", strconv.FormatInt(staging.Id, 10)),
+					resource.TestCheckResourceAttr("data.mixpanel_projects.web_utc", "projects.0.name", "web-staging"),
+					resource.TestCheckResourceAttr("data.mixpanel_projects.web_utc", "projects.0.organization_id", "2"),
+					resource.TestCheckResourceAttr("data.mixpanel_projects.web_utc", "projects.0.domain", "EU"),
+				),
+			},
+		},
+	})
+}
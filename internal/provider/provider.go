@@ -7,19 +7,30 @@ import (
 	"context"
 	"os"
 	"terraform-provider-mixpanel/internal/mixpanel"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
+// defaultTimeout bounds each CRUD operation of a resource when its timeouts
+// block does not set a value.
+const defaultTimeout = 20 * time.Minute
+
 // Ensure MixpanelProvider satisfies various provider interfaces.
 var _ provider.Provider = &MixpanelProvider{}
 var _ provider.ProviderWithFunctions = &MixpanelProvider{}
+var _ provider.ProviderWithEphemeralResources = &MixpanelProvider{}
 
 // MixpanelProvider defines the provider implementation.
 type MixpanelProvider struct {
@@ -36,14 +47,37 @@ func (p *MixpanelProvider) Metadata(ctx context.Context, req provider.MetadataRe
 
 // MixpanelProviderModel maps provider schema data to a Go type.
 type MixpanelProviderModel struct {
+	AuthMethod             types.String `tfsdk:"auth_method"`
 	ServiceAccountUsername types.String `tfsdk:"service_account_username"`
 	ServiceAccountSecret   types.String `tfsdk:"service_account_secret"`
+	ProjectSecret          types.String `tfsdk:"project_secret"`
+	OAuthToken             types.String `tfsdk:"oauth_token"`
+	ApiHost                types.String `tfsdk:"api_host"`
 	ConcurrentRequests     types.Int64  `tfsdk:"concurrent_requests"`
+	MaxRetries             types.Int64  `tfsdk:"max_retries"`
+	RetryWaitMin           types.String `tfsdk:"retry_wait_min"`
+	RetryWaitMax           types.String `tfsdk:"retry_wait_max"`
+	RequestsPerHour        types.Int64  `tfsdk:"requests_per_hour"`
 }
 
+// Authentication methods selectable with the auth_method attribute.
+const (
+	authMethodServiceAccount = "service_account"
+	authMethodProjectSecret  = "project_secret"
+	authMethodOAuthToken     = "oauth_token"
+)
+
 func (p *MixpanelProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
+			"auth_method": schema.StringAttribute{
+				MarkdownDescription: "How to authenticate to Mixpanel, one of `service_account`, `project_secret` or `oauth_token`. " +
+					"Default is `service_account`. (Environment variable: MIXPANEL_AUTH_METHOD)",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(authMethodServiceAccount, authMethodProjectSecret, authMethodOAuthToken),
+				},
+			},
 			"service_account_username": schema.StringAttribute{
 				MarkdownDescription: "Mixpanel Service Account username (Environment variable: MIXPANEL_SERVICE_ACCOUNT_USERNAME)",
 				Optional:            true,
@@ -53,10 +87,48 @@ func (p *MixpanelProvider) Schema(ctx context.Context, req provider.SchemaReques
 				Optional:            true,
 				Sensitive:           true,
 			},
+			"project_secret": schema.StringAttribute{
+				MarkdownDescription: "Mixpanel project API secret, used with the `project_secret` method for the ingestion and export APIs (Environment variable: MIXPANEL_PROJECT_SECRET)",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"oauth_token": schema.StringAttribute{
+				MarkdownDescription: "Mixpanel OAuth or session token, used with the `oauth_token` method (Environment variable: MIXPANEL_OAUTH_TOKEN)",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"api_host": schema.StringAttribute{
+				MarkdownDescription: "Send every request to this host instead of the regional Mixpanel hosts, e.g. to test against a local stand-in (Environment variable: MIXPANEL_API_HOST)",
+				Optional:            true,
+			},
 			"concurrent_requests": schema.Int64Attribute{
 				MarkdownDescription: "The number of concurrent requests to Mixpanel. Default is 3.",
 				Optional:            true,
 			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: "The number of times a rate limited (429) or failed (5xx) request is retried. Default is 4.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"retry_wait_min": schema.StringAttribute{
+				MarkdownDescription: "Minimum time to wait before retrying a failed request, as a Go duration string. Default is `1s`.",
+				Optional:            true,
+			},
+			"retry_wait_max": schema.StringAttribute{
+				MarkdownDescription: "Maximum time to wait before retrying a request, as a Go duration string. " +
+					"Rate limited requests asking to wait longer than this fail right away. Default is `30s`.",
+				Optional: true,
+			},
+			"requests_per_hour": schema.Int64Attribute{
+				MarkdownDescription: "Limit the requests sent to each Mixpanel API family (app, query, ingestion) to this number per hour. " +
+					"The whole hourly budget can be used in a burst. By default only the query API is limited, to its documented 60 requests per hour.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
 		},
 	}
 }
@@ -70,6 +142,22 @@ func (p *MixpanelProvider) Configure(ctx context.Context, req provider.Configure
 		return
 	}
 
+	if config.AuthMethod.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("auth_method"),
+			"Unknown Mixpanel Authentication Method",
+			"The provider cannot create the Mixpanel API client as there is an unknown configuration value for the authentication method. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the MIXPANEL_AUTH_METHOD environment variable.",
+		)
+	}
+	if config.ApiHost.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("api_host"),
+			"Unknown Mixpanel API Host",
+			"The provider cannot create the Mixpanel API client as there is an unknown configuration value for the Mixpanel API host. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the MIXPANEL_API_HOST environment variable.",
+		)
+	}
 	if config.ServiceAccountUsername.IsUnknown() {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("service_account_username"),
@@ -86,12 +174,36 @@ func (p *MixpanelProvider) Configure(ctx context.Context, req provider.Configure
 				"Either target apply the source of the value first, set the value statically in the configuration, or use the MIXPANEL_SERVICE_ACCOUNT_SECRET environment variable.",
 		)
 	}
+	if config.ProjectSecret.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("project_secret"),
+			"Unknown Mixpanel Project Secret",
+			"The provider cannot create the Mixpanel API client as there is an unknown configuration value for the Mixpanel project secret. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the MIXPANEL_PROJECT_SECRET environment variable.",
+		)
+	}
+	if config.OAuthToken.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("oauth_token"),
+			"Unknown Mixpanel OAuth Token",
+			"The provider cannot create the Mixpanel API client as there is an unknown configuration value for the Mixpanel OAuth token. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the MIXPANEL_OAUTH_TOKEN environment variable.",
+		)
+	}
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	authMethod := os.Getenv("MIXPANEL_AUTH_METHOD")
 	serviceAccountUsername := os.Getenv("MIXPANEL_SERVICE_ACCOUNT_USERNAME")
 	serviceAccountSecret := os.Getenv("MIXPANEL_SERVICE_ACCOUNT_SECRET")
+	projectSecret := os.Getenv("MIXPANEL_PROJECT_SECRET")
+	oauthToken := os.Getenv("MIXPANEL_OAUTH_TOKEN")
+	apiHost := os.Getenv("MIXPANEL_API_HOST")
+
+	if !config.AuthMethod.IsNull() {
+		authMethod = config.AuthMethod.ValueString()
+	}
 
 	if !config.ServiceAccountUsername.IsNull() {
 		serviceAccountUsername = config.ServiceAccountUsername.ValueString()
@@ -101,58 +213,192 @@ func (p *MixpanelProvider) Configure(ctx context.Context, req provider.Configure
 		serviceAccountSecret = config.ServiceAccountSecret.ValueString()
 	}
 
+	if !config.ProjectSecret.IsNull() {
+		projectSecret = config.ProjectSecret.ValueString()
+	}
+
+	if !config.OAuthToken.IsNull() {
+		oauthToken = config.OAuthToken.ValueString()
+	}
+
+	if !config.ApiHost.IsNull() {
+		apiHost = config.ApiHost.ValueString()
+	}
+
+	if authMethod == "" {
+		authMethod = authMethodServiceAccount
+	}
+
 	var concurrentRequests int64 = 3
 	if !config.ConcurrentRequests.IsNull() {
 		concurrentRequests = config.ConcurrentRequests.ValueInt64()
 	}
 
-	if serviceAccountUsername == "" {
-		resp.Diagnostics.AddAttributeError(
-			path.Root("service_account_username"),
-			"Mixpanel Service Account Username is required",
-			"The provider cannot create the Mixpanel API client as there is a missing or empty configuration value for the Mixpanel Service Account Username. "+
-				"Either target apply the source of the value first, set the value statically in the configuration, or use the MIXPANEL_SERVICE_ACCOUNT_USERNAME environment variable.",
-		)
+	retryPolicy, diags := providerRetryPolicy(config)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	if serviceAccountSecret == "" {
-		resp.Diagnostics.AddAttributeError(
-			path.Root("service_account_secret"),
-			"Mixpanel Service Account Secret is required",
-			"The provider cannot create the Mixpanel API client as there is missing or empty configuration value for the Mixpanel Service Account password. "+
-				"Either target apply the source of the value first, set the value statically in the configuration, or use the MIXPANEL_SERVICE_ACCOUNT_PASSWORD environment variable.",
-		)
+	var requestsPerHour int64
+	if !config.RequestsPerHour.IsNull() {
+		requestsPerHour = config.RequestsPerHour.ValueInt64()
 	}
 
-	if resp.Diagnostics.HasError() {
+	var authenticator mixpanel.Authenticator
+	switch authMethod {
+	case authMethodServiceAccount:
+		if serviceAccountUsername == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("service_account_username"),
+				"Mixpanel Service Account Username is required",
+				"The provider cannot create the Mixpanel API client as there is a missing or empty configuration value for the Mixpanel Service Account Username. "+
+					"Either target apply the source of the value first, set the value statically in the configuration, or use the MIXPANEL_SERVICE_ACCOUNT_USERNAME environment variable.",
+			)
+		}
+
+		if serviceAccountSecret == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("service_account_secret"),
+				"Mixpanel Service Account Secret is required",
+				"The provider cannot create the Mixpanel API client as there is missing or empty configuration value for the Mixpanel Service Account password. "+
+					"Either target apply the source of the value first, set the value statically in the configuration, or use the MIXPANEL_SERVICE_ACCOUNT_SECRET environment variable.",
+			)
+		}
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		authenticator = &mixpanel.ServiceAccountAuthenticator{Username: serviceAccountUsername, Secret: serviceAccountSecret}
+	case authMethodProjectSecret:
+		if projectSecret == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("project_secret"),
+				"Mixpanel Project Secret is required",
+				"The provider cannot create the Mixpanel API client as the project_secret authentication method is selected but the Mixpanel project secret is missing or empty. "+
+					"Either target apply the source of the value first, set the value statically in the configuration, or use the MIXPANEL_PROJECT_SECRET environment variable.",
+			)
+			return
+		}
+
+		authenticator = &mixpanel.ProjectSecretAuthenticator{Secret: projectSecret}
+	case authMethodOAuthToken:
+		if oauthToken == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("oauth_token"),
+				"Mixpanel OAuth Token is required",
+				"The provider cannot create the Mixpanel API client as the oauth_token authentication method is selected but the Mixpanel OAuth token is missing or empty. "+
+					"Either target apply the source of the value first, set the value statically in the configuration, or use the MIXPANEL_OAUTH_TOKEN environment variable.",
+			)
+			return
+		}
+
+		authenticator = &mixpanel.TokenAuthenticator{Token: oauthToken}
+	default:
+		resp.Diagnostics.AddAttributeError(
+			path.Root("auth_method"),
+			"Invalid Mixpanel Authentication Method",
+			"The authentication method must be one of service_account, project_secret or oauth_token, got: "+authMethod,
+		)
 		return
 	}
 
 	// Create the Mixpanel API client
-	client, err := mixpanel.NewClient(&serviceAccountUsername, &serviceAccountSecret, concurrentRequests)
+	client, err := mixpanel.NewClient(authenticator, concurrentRequests, retryPolicy, requestsPerHour)
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to create Mixpanel API client", err.Error())
 		return
 	}
 
+	if apiHost != "" {
+		client.HostURL = apiHost
+		client.APIHost = apiHost
+	}
+
 	resp.DataSourceData = client
 	resp.ResourceData = client
+	resp.EphemeralResourceData = client
+}
+
+// providerRetryPolicy returns the retry policy of config, the default one
+// for the attributes that are not set.
+func providerRetryPolicy(config MixpanelProviderModel) (mixpanel.RetryPolicy, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	retryPolicy := mixpanel.DefaultRetryPolicy
+	if !config.MaxRetries.IsNull() {
+		retryPolicy.MaxRetries = int(config.MaxRetries.ValueInt64())
+	}
+
+	parseRetryWait := func(attribute string, value types.String, wait *time.Duration) {
+		if value.IsNull() {
+			return
+		}
+
+		duration, err := time.ParseDuration(value.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root(attribute), "Invalid Retry Wait Duration", err.Error())
+			return
+		}
+		if duration < 0 {
+			diags.AddAttributeError(path.Root(attribute), "Invalid Retry Wait Duration", attribute+" must not be negative.")
+			return
+		}
+		*wait = duration
+	}
+	parseRetryWait("retry_wait_min", config.RetryWaitMin, &retryPolicy.RetryWaitMin)
+	parseRetryWait("retry_wait_max", config.RetryWaitMax, &retryPolicy.RetryWaitMax)
+
+	if !diags.HasError() && retryPolicy.RetryWaitMin > retryPolicy.RetryWaitMax {
+		diags.AddAttributeError(
+			path.Root("retry_wait_min"),
+			"Invalid Retry Wait Duration",
+			"retry_wait_min must not be greater than retry_wait_max.",
+		)
+	}
+
+	return retryPolicy, diags
 }
 
 func (p *MixpanelProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewProjectResource,
+		NewDashboardSubscriptionResource,
+		NewGroupKeyResource,
+		NewGdprRequestResource,
+		NewDropFilterResource,
+		NewSessionReplaySettingsResource,
+		NewProjectSecretRotationResource,
+		NewEventImportResource,
+		NewUserProfileResource,
+		NewGroupProfileResource,
+	}
+}
+
+func (p *MixpanelProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewProjectCredentialsEphemeralResource,
 	}
 }
 
 func (p *MixpanelProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewprojectDataSource,
+		NewProjectsDataSource,
 	}
 }
 
 func (p *MixpanelProvider) Functions(ctx context.Context) []func() function.Function {
-	return []func() function.Function{}
+	return []func() function.Function{
+		NewProjectUrlFunction,
+		NewParseProjectUrlFunction,
+		NewNormalizeReportParamsFunction,
+		NewValidateSchemaFunction,
+		NewDeterministicIdFunction,
+		NewIsValidInsertIdFunction,
+	}
 }
 
 func New(version string) func() provider.Provider {
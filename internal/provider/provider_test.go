@@ -4,9 +4,15 @@
 package provider
 
 import (
+	"fmt"
 	"testing"
+	"time"
+
+	"terraform-provider-mixpanel/internal/mixpanel"
+	"terraform-provider-mixpanel/internal/mixpanel/mixpaneltest"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 )
 
@@ -14,14 +20,117 @@ import (
 // acceptance testing. The factory function will be invoked for every Terraform
 // CLI command executed to create a provider server to which the CLI can
 // reattach.
-// nolint:unused
 var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
-	"scaffolding": providerserver.NewProtocol6WithError(New("test")()),
+	"mixpanel": providerserver.NewProtocol6WithError(New("test")()),
 }
 
-// nolint:unused
 func testAccPreCheck(t *testing.T) {
-	// You can add code here to run prior to any test case execution, for example assertions
-	// about the appropriate environment variables being set are common to see in a pre-check
-	// function.
+	// Acceptance tests run against a fake Mixpanel API, credentials from the
+	// environment would be sent to it
+	for _, name := range []string{"MIXPANEL_AUTH_METHOD", "MIXPANEL_API_HOST"} {
+		t.Setenv(name, "")
+	}
+}
+
+// testAccServer starts a fake Mixpanel API stopped at the end of the test.
+func testAccServer(t *testing.T) *mixpaneltest.Server {
+	server := mixpaneltest.NewServer()
+	t.Cleanup(server.Close)
+	return server
+}
+
+// testAccProviderConfig configures the provider against the fake API, with
+// short retry waits to keep simulated failures fast.
+func testAccProviderConfig(server *mixpaneltest.Server) string {
+	return fmt.Sprintf(`
+provider "mixpanel" {
+  api_host                 = %q
+  service_account_username = "test"
+  service_account_secret   = "test"
+  retry_wait_min           = "10ms"
+  retry_wait_max           = "50ms"
+}
+`, server.URL)
+}
+
+// testClient returns a client of the fake API, for the tests calling the
+// resources directly.
+func testClient(t *testing.T, server *mixpaneltest.Server) *mixpanel.Client {
+	retryPolicy := mixpanel.RetryPolicy{
+		MaxRetries:   2,
+		RetryWaitMin: 10 * time.Millisecond,
+		RetryWaitMax: 50 * time.Millisecond,
+	}
+
+	client, err := mixpanel.NewClient(&mixpanel.ServiceAccountAuthenticator{Username: "test", Secret: "test"}, 3, retryPolicy, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.HostURL = server.URL
+	client.APIHost = server.URL
+
+	return client
+}
+
+func TestProviderRetryPolicy(t *testing.T) {
+	tests := map[string]struct {
+		min, max types.String
+		expected mixpanel.RetryPolicy
+		error    string
+	}{
+		"default": {
+			min:      types.StringNull(),
+			max:      types.StringNull(),
+			expected: mixpanel.DefaultRetryPolicy,
+		},
+		"configured": {
+			min:      types.StringValue("0s"),
+			max:      types.StringValue("5s"),
+			expected: mixpanel.RetryPolicy{MaxRetries: mixpanel.DefaultRetryPolicy.MaxRetries, RetryWaitMax: 5 * time.Second},
+		},
+		"negative min": {
+			min:   types.StringValue("-5s"),
+			max:   types.StringNull(),
+			error: "retry_wait_min must not be negative.",
+		},
+		"negative max": {
+			min:   types.StringValue("0s"),
+			max:   types.StringValue("-1s"),
+			error: "retry_wait_max must not be negative.",
+		},
+		"min greater than max": {
+			min:   types.StringValue("10s"),
+			max:   types.StringValue("5s"),
+			error: "retry_wait_min must not be greater than retry_wait_max.",
+		},
+		"invalid": {
+			min:   types.StringValue("soon"),
+			max:   types.StringNull(),
+			error: `time: invalid duration "soon"`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			retryPolicy, diags := providerRetryPolicy(MixpanelProviderModel{
+				MaxRetries:   types.Int64Null(),
+				RetryWaitMin: test.min,
+				RetryWaitMax: test.max,
+			})
+
+			if test.error != "" {
+				if diags.ErrorsCount() != 1 || diags.Errors()[0].Detail() != test.error {
+					t.Fatalf("expected the error %q, got %v", test.error, diags)
+				}
+				return
+			}
+
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if retryPolicy != test.expected {
+				t.Errorf("expected %+v, got %+v", test.expected, retryPolicy)
+			}
+		})
+	}
 }
@@ -0,0 +1,282 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"terraform-provider-mixpanel/internal/mixpanel"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &sessionReplaySettingsResource{}
+	_ resource.ResourceWithConfigure   = &sessionReplaySettingsResource{}
+	_ resource.ResourceWithImportState = &sessionReplaySettingsResource{}
+)
+
+// NewSessionReplaySettingsResource is a helper function to simplify the provider implementation.
+func NewSessionReplaySettingsResource() resource.Resource {
+	return &sessionReplaySettingsResource{}
+}
+
+// sessionReplaySettingsResource is the resource implementation.
+type sessionReplaySettingsResource struct {
+	client *mixpanel.Client
+}
+
+type SessionReplaySettingsModel struct {
+	Id            types.Int64    `tfsdk:"id"`
+	ProjectId     types.Int64    `tfsdk:"project_id"`
+	SamplingRate  types.Float64  `tfsdk:"sampling_rate"`
+	MaskAllText   types.Bool     `tfsdk:"mask_all_text"`
+	MaskAllInputs types.Bool     `tfsdk:"mask_all_inputs"`
+	MaskAllImages types.Bool     `tfsdk:"mask_all_images"`
+	RetentionDays types.Int64    `tfsdk:"retention_days"`
+	Timeouts      timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *sessionReplaySettingsResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*mixpanel.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *mixpanel.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// Metadata returns the resource type name.
+func (r *sessionReplaySettingsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_session_replay_settings"
+}
+
+// Schema defines the schema for the resource.
+func (r *sessionReplaySettingsResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Session replay settings of a Mixpanel project. A project has a single set of settings, " +
+			"destroying the resource leaves them as they are in Mixpanel.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				MarkdownDescription: "Same as `project_id`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_id": schema.Int64Attribute{
+				MarkdownDescription: "ID of the project the settings apply to.",
+				Required:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"sampling_rate": schema.Float64Attribute{
+				MarkdownDescription: "Share of sessions recorded, between `0` and `1`.",
+				Required:            true,
+				Validators: []validator.Float64{
+					float64validator.Between(0, 1),
+				},
+			},
+			"mask_all_text": schema.BoolAttribute{
+				MarkdownDescription: "Whether text content is masked in recordings. Default is `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"mask_all_inputs": schema.BoolAttribute{
+				MarkdownDescription: "Whether form inputs are masked in recordings. Default is `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"mask_all_images": schema.BoolAttribute{
+				MarkdownDescription: "Whether images are masked in recordings. Default is `false`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"retention_days": schema.Int64Attribute{
+				MarkdownDescription: "Number of days recordings are kept. Default is `30`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(30),
+				Validators: []validator.Int64{
+					int64validator.Between(1, 365),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+			}),
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *sessionReplaySettingsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state SessionReplaySettingsModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	settings, err := r.client.GetSessionReplaySettings(ctx, state.ProjectId.ValueInt64())
+	if err != nil {
+		if mixpanel.IsNotFound(err) {
+			// The project was deleted along with its settings
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error Reading Mixpanel Session Replay Settings",
+			"Could not read session replay settings of Mixpanel project ID "+strconv.FormatInt(state.ProjectId.ValueInt64(), 10)+": "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, SessionReplaySettingsToModel(state.ProjectId.ValueInt64(), settings, state.Timeouts))
+	resp.Diagnostics.Append(diags...)
+}
+
+// Create applies the settings to the project and sets the initial Terraform state.
+func (r *sessionReplaySettingsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan SessionReplaySettingsModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	settings, err := r.client.UpdateSessionReplaySettings(ctx, plan.ProjectId.ValueInt64(), SessionReplaySettingsModelToSettings(&plan))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to update Mixpanel Session Replay Settings",
+			err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, SessionReplaySettingsToModel(plan.ProjectId.ValueInt64(), settings, plan.Timeouts))
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *sessionReplaySettingsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan SessionReplaySettingsModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	settings, err := r.client.UpdateSessionReplaySettings(ctx, plan.ProjectId.ValueInt64(), SessionReplaySettingsModelToSettings(&plan))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to update Mixpanel Session Replay Settings",
+			err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, SessionReplaySettingsToModel(plan.ProjectId.ValueInt64(), settings, plan.Timeouts))
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete removes the resource from the Terraform state.
+func (r *sessionReplaySettingsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Settings always exist for a project, they are left untouched rather than reset to values nobody chose
+}
+
+func (r *sessionReplaySettingsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	ids, err := parseImportId(req.ID, "project_id")
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid ID",
+			err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), ids[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), ids[0])...)
+}
+
+func SessionReplaySettingsModelToSettings(model *SessionReplaySettingsModel) *mixpanel.SessionReplaySettings {
+	return &mixpanel.SessionReplaySettings{
+		SamplingRate:  model.SamplingRate.ValueFloat64(),
+		MaskAllText:   model.MaskAllText.ValueBool(),
+		MaskAllInputs: model.MaskAllInputs.ValueBool(),
+		MaskAllImages: model.MaskAllImages.ValueBool(),
+		RetentionDays: model.RetentionDays.ValueInt64(),
+	}
+}
+
+func SessionReplaySettingsToModel(projectId int64, settings *mixpanel.SessionReplaySettings, modelTimeouts timeouts.Value) SessionReplaySettingsModel {
+	return SessionReplaySettingsModel{
+		Id:            types.Int64Value(projectId),
+		ProjectId:     types.Int64Value(projectId),
+		SamplingRate:  types.Float64Value(settings.SamplingRate),
+		MaskAllText:   types.BoolValue(settings.MaskAllText),
+		MaskAllInputs: types.BoolValue(settings.MaskAllInputs),
+		MaskAllImages: types.BoolValue(settings.MaskAllImages),
+		RetentionDays: types.Int64Value(settings.RetentionDays),
+		Timeouts:      modelTimeouts,
+	}
+}
@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"terraform-provider-mixpanel/internal/mixpanel"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccSessionReplaySettingsResource(t *testing.T) {
+	server := testAccServer(t)
+	project := server.AddProject(mixpanel.Project{Name: "test"})
+
+	// testAccCheckSettings checks the settings of the project in Mixpanel
+	testAccCheckSettings := func(expected mixpanel.SessionReplaySettings) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			settings, _ := server.SessionReplaySettings(project.Id)
+			if settings != expected {
+				return fmt.Errorf("expected settings %+v, got %+v", expected, settings)
+			}
+			return nil
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing, with the default masking and retention
+			{
+				Config: testAccProviderConfig(server) + fmt.Sprintf(`
+resource "mixpanel_session_replay_settings" "test" {
+  project_id    = %d
+  sampling_rate = 0.25
+}
+`, project.Id),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mixpanel_session_replay_settings.test", "id", strconv.FormatInt(project.Id, 10)),
+					resource.TestCheckResourceAttr("mixpanel_session_replay_settings.test", "retention_days", "30"),
+					testAccCheckSettings(mixpanel.SessionReplaySettings{
+						SamplingRate:  0.25,
+						MaskAllText:   true,
+						MaskAllInputs: true,
+						RetentionDays: 30,
+					}),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "mixpanel_session_replay_settings.test",
+				ImportState:             true,
+				ImportStateId:           strconv.FormatInt(project.Id, 10),
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"timeouts"},
+			},
+			// Update testing
+			{
+				Config: testAccProviderConfig(server) + fmt.Sprintf(`
+resource "mixpanel_session_replay_settings" "test" {
+  project_id      = %d
+  sampling_rate   = 1
+  mask_all_text   = false
+  mask_all_images = true
+  retention_days  = 90
+}
+`, project.Id),
+				Check: testAccCheckSettings(mixpanel.SessionReplaySettings{
+					SamplingRate:  1,
+					MaskAllInputs: true,
+					MaskAllImages: true,
+					RetentionDays: 90,
+				}),
+			},
+			// The settings of a project deleted outside of Terraform are removed from the state
+			{
+				PreConfig: func() {
+					server.DeleteProject(project.Id)
+				},
+				RefreshState:       true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,313 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"terraform-provider-mixpanel/internal/mixpanel"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                     = &userProfileResource{}
+	_ resource.ResourceWithConfigure        = &userProfileResource{}
+	_ resource.ResourceWithImportState      = &userProfileResource{}
+	_ resource.ResourceWithValidateConfig   = &userProfileResource{}
+	_ resource.ResourceWithConfigValidators = &userProfileResource{}
+)
+
+// NewUserProfileResource is a helper function to simplify the provider implementation.
+func NewUserProfileResource() resource.Resource {
+	return &userProfileResource{}
+}
+
+// userProfileResource is the resource implementation.
+type userProfileResource struct {
+	client *mixpanel.Client
+}
+
+type UserProfileModel struct {
+	Id         types.String   `tfsdk:"id"`
+	ProjectId  types.Int64    `tfsdk:"project_id"`
+	DistinctId types.String   `tfsdk:"distinct_id"`
+	Properties types.String   `tfsdk:"properties"`
+	SetOnce    types.String   `tfsdk:"set_once"`
+	Unset      types.Set      `tfsdk:"unset"`
+	Timeouts   timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *userProfileResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*mixpanel.Client)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *mixpanel.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// Metadata returns the resource type name.
+func (r *userProfileResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user_profile"
+}
+
+// Schema defines the schema for the resource.
+func (r *userProfileResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Properties of a user profile, updated through the Engage API, e.g. for test and QA accounts. " +
+			"Only the properties set in `properties` are managed, changes made to them outside of Terraform are detected " +
+			"through the Engage query API. Destroying the resource removes these properties from the profile. " +
+			"Each refresh reads the profile with one request to the query API, limited to 60 requests per hour " +
+			"(see `requests_per_hour` of the provider). Past the first 60 profiles refreshed within an hour, " +
+			"each one waits about a minute for the limit.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Project ID and distinct ID, separated by `/`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_id": schema.Int64Attribute{
+				MarkdownDescription: "ID of the project of the profile.",
+				Required:            true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"distinct_id": schema.StringAttribute{
+				MarkdownDescription: "Distinct ID of the user.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"properties": profilePropertiesAttribute("Properties set on the profile with `$set`, as a JSON object, e.g. with `jsonencode`. " +
+				"Properties removed from the object are removed from the profile."),
+			"set_once": profilePropertiesAttribute("Properties set with `$set_once`, as a JSON object: they are only set when the profile " +
+				"does not have them yet, and are not checked for changes afterwards."),
+			"unset": profileUnsetAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// ConfigValidators returns the validators of the resource configuration.
+func (r *userProfileResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.AtLeastOneOf(
+			path.MatchRoot("properties"),
+			path.MatchRoot("set_once"),
+			path.MatchRoot("unset"),
+		),
+	}
+}
+
+// ValidateConfig checks the properties of the profile.
+func (r *userProfileResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config UserProfileModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateProfileConfig(ctx, config.Properties, config.SetOnce, config.Unset, &resp.Diagnostics)
+}
+
+// Read refreshes the managed properties with the profile in Mixpanel.
+func (r *userProfileResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state UserProfileModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readTimeout, diags := state.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	properties, found := readProfile(ctx, r.client, state.ProjectId.ValueInt64(), state.target(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.Id = types.StringValue(fmt.Sprintf("%d/%s", state.ProjectId.ValueInt64(), state.DistinctId.ValueString()))
+	state.Properties = refreshProfileProperties(state.Properties, properties, &resp.Diagnostics)
+	state.Unset = refreshProfileUnset(ctx, state.Unset, properties, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Create updates the profile and sets the initial Terraform state.
+func (r *userProfileResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan UserProfileModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
+	update := profileUpdate(ctx, plan.Properties, types.StringNull(), plan.SetOnce, plan.Unset, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateProfile(ctx, r.client, plan.ProjectId.ValueInt64(), plan.target(), update, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.Id = types.StringValue(fmt.Sprintf("%d/%s", plan.ProjectId.ValueInt64(), plan.DistinctId.ValueString()))
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the profile and sets the updated Terraform state on success.
+func (r *userProfileResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan UserProfileModel
+	var state UserProfileModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateTimeout, diags := plan.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
+	update := profileUpdate(ctx, plan.Properties, state.Properties, plan.SetOnce, plan.Unset, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateProfile(ctx, r.client, plan.ProjectId.ValueInt64(), plan.target(), update, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete removes the managed properties from the profile.
+func (r *userProfileResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state UserProfileModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	deleteProfile(ctx, r.client, state.ProjectId.ValueInt64(), state.target(), state.Properties, &resp.Diagnostics)
+}
+
+func (r *userProfileResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// The distinct ID may contain "/", only the first one separates the parts
+	parts := strings.SplitN(req.ID, "/", 2)
+	projectId, err := strconv.ParseInt(parts[0], 10, 64)
+	if len(parts) != 2 || err != nil || parts[1] == "" {
+		resp.Diagnostics.AddError(
+			"Invalid ID",
+			fmt.Sprintf("expected import identifier with format project_id/distinct_id, got %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), projectId)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("distinct_id"), parts[1])...)
+}
+
+// target returns the profile of the distinct ID of the model.
+func (m UserProfileModel) target() userProfileTarget {
+	return userProfileTarget{distinctId: m.DistinctId.ValueString()}
+}
+
+// userProfileTarget is the profile of a distinct ID.
+type userProfileTarget struct {
+	distinctId string
+}
+
+func (t userProfileTarget) kind() string {
+	return "User Profile"
+}
+
+func (t userProfileTarget) String() string {
+	return "the profile of " + t.distinctId
+}
+
+func (t userProfileTarget) get(ctx context.Context, client *mixpanel.Client, project *mixpanel.Project) (map[string]interface{}, error) {
+	return client.GetUserProfile(ctx, project.Id, project.Domain, t.distinctId)
+}
+
+func (t userProfileTarget) update(ctx context.Context, client *mixpanel.Client, project *mixpanel.Project, update mixpanel.ProfileUpdate) error {
+	return client.UpdateUserProfile(ctx, project.Domain, project.Token, t.distinctId, update)
+}
@@ -0,0 +1,99 @@
+package provider
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"terraform-provider-mixpanel/internal/mixpanel"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func testAccUserProfileConfig(projectId int64, role string) string {
+	return fmt.Sprintf(`
+resource "mixpanel_user_profile" "test" {
+  project_id  = %d
+  distinct_id = "qa-1"
+
+  properties = jsonencode({
+    "$email" = "qa-1@example.com"
+    role     = %q
+    seats    = 3
+  })
+  set_once = jsonencode({
+    first_seen = "2024-01-01"
+  })
+  unset = ["legacy_plan"]
+}
+`, projectId, role)
+}
+
+func TestAccUserProfileResource(t *testing.T) {
+	server := testAccServer(t)
+	project := server.AddProject(mixpanel.Project{Name: "test"})
+	server.SetUserProfile(project.Id, "qa-1", map[string]interface{}{"legacy_plan": "gold", "first_seen": "2023-01-01"})
+
+	// testAccCheckProfile checks the properties of the profile in Mixpanel
+	testAccCheckProfile := func(expected map[string]interface{}) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			profile, _ := server.UserProfile(project.Id, "qa-1")
+			if !reflect.DeepEqual(profile, expected) {
+				return fmt.Errorf("expected profile %v, got %v", expected, profile)
+			}
+			return nil
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server) + testAccUserProfileConfig(project.Id, "qa"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("mixpanel_user_profile.test", "id", fmt.Sprintf("%d/qa-1", project.Id)),
+					testAccCheckProfile(map[string]interface{}{
+						"$email":     "qa-1@example.com",
+						"role":       "qa",
+						"seats":      float64(3),
+						"first_seen": "2023-01-01",
+					}),
+				),
+			},
+			// ImportState testing, only the identifiers can be imported
+			{
+				ResourceName:            "mixpanel_user_profile.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"properties", "set_once", "unset", "timeouts"},
+			},
+			// Changes made outside of Terraform are reverted
+			{
+				PreConfig: func() {
+					server.SetUserProfile(project.Id, "qa-1", map[string]interface{}{"role": "admin", "legacy_plan": "gold"})
+				},
+				Config: testAccProviderConfig(server) + testAccUserProfileConfig(project.Id, "qa"),
+				Check: testAccCheckProfile(map[string]interface{}{
+					"$email":     "qa-1@example.com",
+					"role":       "qa",
+					"seats":      float64(3),
+					"first_seen": "2024-01-01",
+				}),
+			},
+			// Update testing
+			{
+				Config: testAccProviderConfig(server) + testAccUserProfileConfig(project.Id, "support"),
+				Check: testAccCheckProfile(map[string]interface{}{
+					"$email":     "qa-1@example.com",
+					"role":       "support",
+					"seats":      float64(3),
+					"first_seen": "2024-01-01",
+				}),
+			},
+		},
+		// Destroying the resource removes the managed properties
+		CheckDestroy: testAccCheckProfile(map[string]interface{}{"first_seen": "2024-01-01"}),
+	})
+}
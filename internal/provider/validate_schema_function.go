@@ -0,0 +1,202 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &validateSchemaFunction{}
+
+// Entity types of the Lexicon schemas API.
+var schemaEntityTypes = map[string]bool{
+	"event":        true,
+	"profile":      true,
+	"custom_event": true,
+}
+
+// JSON Schema types Mixpanel accepts for properties.
+var schemaPropertyTypes = map[string]bool{
+	"string":  true,
+	"number":  true,
+	"integer": true,
+	"boolean": true,
+	"array":   true,
+	"object":  true,
+	"null":    true,
+}
+
+// NewValidateSchemaFunction is a helper function to simplify the provider implementation.
+func NewValidateSchemaFunction() function.Function {
+	return &validateSchemaFunction{}
+}
+
+// validateSchemaFunction checks Lexicon schema documents.
+type validateSchemaFunction struct{}
+
+func (f *validateSchemaFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "validate_schema"
+}
+
+func (f *validateSchemaFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Check the structure of a Mixpanel Lexicon schema document",
+		MarkdownDescription: "Checks a Mixpanel Lexicon schema document, either a single entry or an object with an `entries` list, " +
+			"and returns the structural errors found, such as a missing `name`, an unknown `entityType` or an unsupported property `type`. " +
+			"An empty list means the document is valid. Fails when the document is not valid JSON.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "json",
+				MarkdownDescription: "Schema document as JSON.",
+			},
+		},
+		Return: function.ListReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+func (f *validateSchemaFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var document string
+
+	resp.Error = req.Arguments.Get(ctx, &document)
+	if resp.Error != nil {
+		return
+	}
+
+	value, err := decodeJSONDocument(document)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, validateSchema(value))
+}
+
+func validateSchema(value interface{}) []string {
+	errs := []string{}
+
+	root, ok := value.(map[string]interface{})
+	if !ok {
+		return append(errs, "schema must be a JSON object")
+	}
+
+	entries, ok := root["entries"]
+	if !ok {
+		return validateSchemaEntry("", root, errs)
+	}
+
+	list, ok := entries.([]interface{})
+	if !ok {
+		return append(errs, "entries: must be a list")
+	}
+
+	for i, entry := range list {
+		path := fmt.Sprintf("entries[%d]", i)
+
+		object, ok := entry.(map[string]interface{})
+		if !ok {
+			errs = append(errs, path+": must be an object")
+			continue
+		}
+
+		errs = validateSchemaEntry(path+".", object, errs)
+	}
+
+	return errs
+}
+
+func validateSchemaEntry(path string, entry map[string]interface{}, errs []string) []string {
+	entityType, ok := entry["entityType"].(string)
+	if !ok {
+		errs = append(errs, path+"entityType: must be a string")
+	} else if !schemaEntityTypes[entityType] {
+		errs = append(errs, fmt.Sprintf("%sentityType: unknown entity type %q", path, entityType))
+	}
+
+	if name, ok := entry["name"].(string); !ok || name == "" {
+		errs = append(errs, path+"name: must be a non-empty string")
+	}
+
+	schemaJson, ok := entry["schemaJson"].(map[string]interface{})
+	if !ok {
+		return append(errs, path+"schemaJson: must be an object")
+	}
+
+	path += "schemaJson."
+
+	if description, ok := schemaJson["description"]; ok {
+		if _, ok := description.(string); !ok {
+			errs = append(errs, path+"description: must be a string")
+		}
+	}
+
+	if metadata, ok := schemaJson["metadata"]; ok {
+		if _, ok := metadata.(map[string]interface{}); !ok {
+			errs = append(errs, path+"metadata: must be an object")
+		}
+	}
+
+	properties, ok := schemaJson["properties"]
+	if !ok {
+		return errs
+	}
+
+	propertyMap, ok := properties.(map[string]interface{})
+	if !ok {
+		return append(errs, path+"properties: must be an object")
+	}
+
+	names := make([]string, 0, len(propertyMap))
+	for name := range propertyMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		errs = validateSchemaProperty(fmt.Sprintf("%sproperties[%q]", path, name), propertyMap[name], errs)
+	}
+
+	return errs
+}
+
+func validateSchemaProperty(path string, value interface{}, errs []string) []string {
+	property, ok := value.(map[string]interface{})
+	if !ok {
+		return append(errs, path+": must be an object")
+	}
+
+	if description, ok := property["description"]; ok {
+		if _, ok := description.(string); !ok {
+			errs = append(errs, path+".description: must be a string")
+		}
+	}
+
+	propertyType, ok := property["type"]
+	if !ok {
+		return errs
+	}
+
+	// The type is a name or a list of names, e.g. ["string", "null"]
+	typeNames, ok := propertyType.([]interface{})
+	if !ok {
+		typeNames = []interface{}{propertyType}
+	}
+
+	for _, typeName := range typeNames {
+		name, ok := typeName.(string)
+		if !ok {
+			errs = append(errs, path+".type: must be a string or a list of strings")
+			continue
+		}
+		if !schemaPropertyTypes[name] {
+			errs = append(errs, fmt.Sprintf("%s.type: unsupported type %q", path, name))
+		}
+	}
+
+	return errs
+}
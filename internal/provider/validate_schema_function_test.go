@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidateSchema(t *testing.T) {
+	tests := map[string]struct {
+		document string
+		errs     []string
+	}{
+		"valid entry": {
+			document: `{"entityType": "event", "name": "Sign Up", "schemaJson": {"description": "A user signed up", "properties": {"plan": {"type": ["string", "null"]}}}}`,
+			errs:     []string{},
+		},
+		"valid entries": {
+			document: `{"entries": [{"entityType": "profile", "name": "$email", "schemaJson": {"metadata": {"com.mixpanel": {"hidden": false}}}}]}`,
+			errs:     []string{},
+		},
+		"invalid entries": {
+			document: `{"entries": [{"entityType": "page", "schemaJson": {"properties": {"price": {"type": "decimal"}, "id": 1}}}, 2]}`,
+			errs: []string{
+				`entries[0].entityType: unknown entity type "page"`,
+				`entries[0].name: must be a non-empty string`,
+				`entries[0].schemaJson.properties["id"]: must be an object`,
+				`entries[0].schemaJson.properties["price"].type: unsupported type "decimal"`,
+				`entries[1]: must be an object`,
+			},
+		},
+		"not an object": {
+			document: `[]`,
+			errs:     []string{"schema must be a JSON object"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			value, err := decodeJSONDocument(test.document)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if errs := validateSchema(value); !reflect.DeepEqual(errs, test.errs) {
+				t.Errorf("got %q, want %q", errs, test.errs)
+			}
+		})
+	}
+}